@@ -109,6 +109,12 @@ resource "sevalla_pipeline" "api_pipeline" {
   app_id      = sevalla_application.api.id
   branch      = var.api_branch
   auto_deploy = true
+
+  stages = [
+    { display_name = "Preview", type = "preview" },
+    { display_name = "Staging", type = "standard" },
+    { display_name = "Production", type = "standard" },
+  ]
 }
 
 # CI/CD pipeline for frontend with automatic deployment
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ApplicationBuildTimesDataSource{}
+
+func NewApplicationBuildTimesDataSource() datasource.DataSource {
+	return &ApplicationBuildTimesDataSource{}
+}
+
+// ApplicationBuildTimesDataSource defines the data source implementation.
+type ApplicationBuildTimesDataSource struct {
+	client *sevallaapi.Client
+}
+
+// ApplicationBuildTimesDataSourceModel describes the data source data model.
+type ApplicationBuildTimesDataSourceModel struct {
+	AppID         types.String `tfsdk:"app_id"`
+	StartDate     types.String `tfsdk:"start_date"`
+	EndDate       types.String `tfsdk:"end_date"`
+	Interval      types.String `tfsdk:"interval"`
+	Timeframe     types.List   `tfsdk:"timeframe"`
+	Data          types.List   `tfsdk:"data"`
+	Unit          types.String `tfsdk:"unit"`
+	ResourceTypes types.List   `tfsdk:"resource_types"`
+}
+
+func (d *ApplicationBuildTimesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_build_times"
+}
+
+func (d *ApplicationBuildTimesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"app_id": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The ID of the application.",
+		},
+		"unit": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The unit of the build times in `data`. Null if the API did not report one.",
+		},
+		"resource_types": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "The build machine size of each data point, in the same order as `data`.",
+		},
+	}
+	maps.Copy(attributes, metricsQueryAttributes())
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches how long the builds of a Sevalla application took in each interval of a timeframe, " +
+			"for example to spot build time regressions after dependency changes.",
+		Attributes: attributes,
+	}
+}
+
+func (d *ApplicationBuildTimesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(SevallaProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected SevallaProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *ApplicationBuildTimesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ApplicationBuildTimesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metrics, err := d.client.Applications.GetBuildTimeMetrics(ctx, data.AppID.ValueString(),
+		newMetricsQuery(data.StartDate, data.EndDate, data.Interval))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read application build time metrics, got error: %s", err))
+		return
+	}
+
+	data.Timeframe, data.Data = flattenMetricsSeries(ctx, metrics.Timeframe, metrics.Data, &resp.Diagnostics)
+	resourceTypes, diags := types.ListValueFrom(ctx, types.StringType, metrics.ResourceTypes)
+	resp.Diagnostics.Append(diags...)
+	data.ResourceTypes = resourceTypes
+	data.Unit = types.StringNull()
+	if metrics.Unit != "" {
+		data.Unit = types.StringValue(metrics.Unit)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestApplicationBuildTimesDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	d := &ApplicationBuildTimesDataSource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	read := func(interval string) (ApplicationBuildTimesDataSourceModel, *datasource.ReadResponse) {
+		values := testNullValues(objectType)
+		values["app_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.ApplicationID)
+		values["start_date"] = tftypes.NewValue(tftypes.String, "2024-01-01")
+		values["end_date"] = tftypes.NewValue(tftypes.String, "2024-01-02")
+		values["interval"] = tftypes.NewValue(tftypes.String, interval)
+
+		req := datasource.ReadRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}
+		resp := &datasource.ReadResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		d.Read(ctx, req, resp)
+
+		var data ApplicationBuildTimesDataSourceModel
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		}
+		return data, resp
+	}
+
+	t.Run("series", func(t *testing.T) {
+		data, resp := read("day")
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var values []float64
+		var resourceTypes []string
+		resp.Diagnostics.Append(data.Data.ElementsAs(ctx, &values, false)...)
+		resp.Diagnostics.Append(data.ResourceTypes.ElementsAs(ctx, &resourceTypes, false)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if len(values) != 2 || values[1] != 140.5 {
+			t.Errorf("unexpected data %v", values)
+		}
+		if len(resourceTypes) != 2 || resourceTypes[0] != "build_s" {
+			t.Errorf("unexpected resource types %v", resourceTypes)
+		}
+		if len(data.Timeframe.Elements()) != 2 || !data.Unit.IsNull() {
+			t.Errorf("unexpected timeframe %s and unit %s", data.Timeframe, data.Unit)
+		}
+
+		req, _ := server.LastRequest()
+		if req.Path != "/applications/app-1/metrics/build-time" || req.Query.Get("interval_in_seconds") != "86400" {
+			t.Errorf("unexpected request %s?%s", req.Path, req.Query.Encode())
+		}
+	})
+
+	t.Run("empty series", func(t *testing.T) {
+		server.HandleJSON(http.MethodGet, "/applications/{id}/metrics/build-time", http.StatusOK,
+			`{"app": {"id": "app-1", "metrics": {"build_time": []}}}`)
+
+		data, resp := read("week")
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		for name, list := range map[string]int{
+			"timeframe":      len(data.Timeframe.Elements()),
+			"data":           len(data.Data.Elements()),
+			"resource_types": len(data.ResourceTypes.Elements()),
+		} {
+			if list != 0 {
+				t.Errorf("expected %s to be empty, got %d elements", name, list)
+			}
+		}
+		if data.Data.IsNull() || data.Timeframe.IsNull() || data.ResourceTypes.IsNull() {
+			t.Error("expected empty lists rather than null")
+		}
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		_, resp := read("minute")
+		if !resp.Diagnostics.HasError() {
+			t.Error("expected an error for an unsupported interval")
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ApplicationByNameDataSource{}
+
+func NewApplicationByNameDataSource() datasource.DataSource {
+	return &ApplicationByNameDataSource{}
+}
+
+// ApplicationByNameDataSource looks up an application by name and exposes the same
+// attributes as ApplicationDataSource, so it shares ApplicationDataSourceModel.
+type ApplicationByNameDataSource struct {
+	client *sevallaapi.Client
+}
+
+func (d *ApplicationByNameDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_by_name"
+}
+
+func (d *ApplicationByNameDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	var base datasource.SchemaResponse
+	(&ApplicationDataSource{}).Schema(ctx, req, &base)
+
+	nameValidators := []validator.String{
+		stringvalidator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("display_name")),
+	}
+	attributes := base.Schema.Attributes
+	attributes["id"] = schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "The unique identifier of the matching application.",
+	}
+	attributes["company_id"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "The company ID whose applications are searched.",
+	}
+	attributes["name"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "The unique name of the application to look up. Exactly one of `name` and `display_name` must be set.",
+		Validators:          nameValidators,
+	}
+	attributes["display_name"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "The display name of the application to look up. Exactly one of `name` and `display_name` must be set.",
+		Validators:          nameValidators,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches information about the Sevalla application in a company with the given name or display name. " +
+			"Reading fails unless exactly one application matches.",
+		Attributes: attributes,
+	}
+}
+
+func (d *ApplicationByNameDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(SevallaProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected SevallaProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *ApplicationByNameDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ApplicationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	field, want := "name", data.Name.ValueString()
+	if data.Name.IsNull() {
+		field, want = "display_name", data.DisplayName.ValueString()
+	}
+
+	tflog.Debug(ctx, "Looking up application by name", map[string]interface{}{
+		"company_id": data.CompanyID.ValueString(),
+		field:        want,
+	})
+
+	apps, err := d.client.Applications.List(ctx, data.CompanyID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list applications, got error: %s", err))
+		return
+	}
+
+	var matches []string
+	for _, app := range apps {
+		got := app.Name
+		if field == "display_name" {
+			got = app.DisplayName
+		}
+		if got == want {
+			matches = append(matches, app.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"Application Not Found",
+			fmt.Sprintf("No application in company %s has %s %q.", data.CompanyID.ValueString(), field, want),
+		)
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddError(
+			"Multiple Applications Found",
+			fmt.Sprintf("%d applications in company %s have %s %q: %s. Use a unique name or the sevalla_application data source with an ID.",
+				len(matches), data.CompanyID.ValueString(), field, want, strings.Join(matches, ", ")),
+		)
+		return
+	}
+
+	app, err := d.client.Applications.Get(ctx, matches[0])
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read application, got error: %s", err))
+		return
+	}
+
+	(&ApplicationDataSource{}).mapApplicationToModel(ctx, &data, &app.App)
+
+	tflog.Trace(ctx, "Read application by name data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestApplicationByNameDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	d := &ApplicationByNameDataSource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	read := func(field, value string) (ApplicationDataSourceModel, *datasource.ReadResponse) {
+		values := testNullValues(objectType)
+		values["company_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.CompanyID)
+		values[field] = tftypes.NewValue(tftypes.String, value)
+
+		req := datasource.ReadRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}
+		resp := &datasource.ReadResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		d.Read(ctx, req, resp)
+
+		var data ApplicationDataSourceModel
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		}
+		return data, resp
+	}
+
+	for field, value := range map[string]string{"name": "my-app-abc12", "display_name": "my-app"} {
+		t.Run("match by "+field, func(t *testing.T) {
+			data, resp := read(field, value)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if data.ID.ValueString() != sevallaapitest.ApplicationID || data.Name.ValueString() != "my-app-abc12" ||
+				data.DisplayName.ValueString() != "my-app" || data.RepoURL.ValueString() != "https://github.com/example/my-app" {
+				t.Errorf("unexpected application %s (%s, %s, %s)", data.ID, data.Name, data.DisplayName, data.RepoURL)
+			}
+
+			req, _ := server.LastRequest()
+			if req.Path != "/applications/app-1" {
+				t.Errorf("expected the matching application to be read, got %s", req.Path)
+			}
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		_, resp := read("display_name", "missing")
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Application Not Found" {
+			t.Errorf("expected a not found error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		server.HandleJSON(http.MethodGet, "/applications", http.StatusOK, `{"company": {"apps": {"items": [
+			{"id": "app-1", "name": "my-app-abc12", "display_name": "my-app", "status": "deployed"},
+			{"id": "app-2", "name": "my-app-def34", "display_name": "my-app", "status": "deployed"}
+		]}}}`)
+
+		_, resp := read("display_name", "my-app")
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Multiple Applications Found" {
+			t.Errorf("expected an ambiguity error, got %v", resp.Diagnostics)
+		}
+		for _, req := range server.Requests() {
+			if req.Path == "/applications/app-2" {
+				t.Error("expected no application to be read when the name is ambiguous")
+			}
+		}
+	})
+}
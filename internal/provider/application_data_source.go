@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
@@ -75,8 +76,9 @@ func (d *ApplicationDataSource) Schema(ctx context.Context, req datasource.Schem
 				MarkdownDescription: "The current status of the application (deploying, deployed, failed, stopped).",
 			},
 			"company_id": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "The company ID that owns this application.",
+				MarkdownDescription: "The company ID that owns this application. When set, reading fails unless the application belongs to this company.",
 			},
 			"repo_url": schema.StringAttribute{
 				Computed:            true,
@@ -120,7 +122,7 @@ func (d *ApplicationDataSource) Schema(ctx context.Context, req datasource.Schem
 			},
 			"environment_variables": schema.ListNestedAttribute{
 				Computed:            true,
-				MarkdownDescription: "Environment variables for the application.",
+				MarkdownDescription: "Environment variables for the application, sorted by key.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"key": schema.StringAttribute{
@@ -216,6 +218,11 @@ func (d *ApplicationDataSource) Schema(ctx context.Context, req datasource.Schem
 							Computed:            true,
 							MarkdownDescription: "The process entrypoint.",
 						},
+						"scaling_strategy": schema.SingleNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "The scaling strategy of the process. Null when the process has none.",
+							Attributes:          scalingStrategyDataSourceAttributes(),
+						},
 					},
 				},
 			},
@@ -282,6 +289,15 @@ func (d *ApplicationDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
+	if !data.CompanyID.IsNull() && app.App.CompanyID != data.CompanyID.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("company_id"),
+			"Application Company Mismatch",
+			fmt.Sprintf("Application %s belongs to company %s, not %s.", data.ID.ValueString(), app.App.CompanyID, data.CompanyID.ValueString()),
+		)
+		return
+	}
+
 	// Map all fields from API response using the same logic as the resource
 	d.mapApplicationToModel(ctx, &data, &app.App)
 
@@ -314,9 +330,10 @@ func (d *ApplicationDataSource) mapApplicationToModel(ctx context.Context, data
 	data.StartCommand = types.StringValue(app.StartCommand)
 	data.InstallCommand = types.StringValue(app.InstallCommand)
 
-	// Convert environment variables
+	// Convert environment variables, sorted by key so the API's ordering does not cause diffs
+	noPrior := types.ListNull(types.ObjectType{AttrTypes: environmentVariableAttrTypes})
 	envVars := make([]attr.Value, len(app.EnvironmentVariables))
-	for i, envVar := range app.EnvironmentVariables {
+	for i, envVar := range orderEnvVars(ctx, noPrior, app.EnvironmentVariables) {
 		envVarObj, _ := types.ObjectValue(
 			map[string]attr.Type{
 				"key":   types.StringType,
@@ -381,17 +398,19 @@ func (d *ApplicationDataSource) mapApplicationToModel(ctx context.Context, data
 	data.Deployments, _ = types.ListValue(types.ObjectType{AttrTypes: deploymentAttrTypes}, deployments)
 
 	// Convert processes
+	processAttrTypes := map[string]attr.Type{
+		"id":                 types.StringType,
+		"key":                types.StringType,
+		"type":               types.StringType,
+		"display_name":       types.StringType,
+		"resource_type_name": types.StringType,
+		"entrypoint":         types.StringType,
+		"scaling_strategy":   types.ObjectType{AttrTypes: scalingStrategyDataSourceAttrTypes()},
+	}
 	processes := make([]attr.Value, len(app.Processes))
 	for i, process := range app.Processes {
 		processObj, _ := types.ObjectValue(
-			map[string]attr.Type{
-				"id":                 types.StringType,
-				"key":                types.StringType,
-				"type":               types.StringType,
-				"display_name":       types.StringType,
-				"resource_type_name": types.StringType,
-				"entrypoint":         types.StringType,
-			},
+			processAttrTypes,
 			map[string]attr.Value{
 				"id":                 types.StringValue(process.ID),
 				"key":                types.StringValue(process.Key),
@@ -399,18 +418,11 @@ func (d *ApplicationDataSource) mapApplicationToModel(ctx context.Context, data
 				"display_name":       types.StringValue(process.DisplayName),
 				"resource_type_name": types.StringValue(process.ResourceTypeName),
 				"entrypoint":         types.StringValue(process.Entrypoint),
+				"scaling_strategy":   scalingStrategyDataSourceValue(process.ScalingStrategy),
 			},
 		)
 		processes[i] = processObj
 	}
-	processAttrTypes := map[string]attr.Type{
-		"id":                 types.StringType,
-		"key":                types.StringType,
-		"type":               types.StringType,
-		"display_name":       types.StringType,
-		"resource_type_name": types.StringType,
-		"entrypoint":         types.StringType,
-	}
 	data.Processes, _ = types.ListValue(types.ObjectType{AttrTypes: processAttrTypes}, processes)
 
 	// Convert internal connections
@@ -440,3 +452,53 @@ func (d *ApplicationDataSource) mapApplicationToModel(ctx context.Context, data
 	}
 	data.InternalConnections, _ = types.ListValue(types.ObjectType{AttrTypes: connAttrTypes}, connections)
 }
+
+// scalingStrategyDataSourceAttributes returns the read-only schema of a scaling strategy,
+// with one typed attribute per known config key.
+func scalingStrategyDataSourceAttributes() map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The scaling strategy type (`manual` or `horizontal`).",
+		},
+	}
+	for key := range scalingConfigAPIKeys {
+		attributes[key] = schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: fmt.Sprintf("The `%s` scaling setting. Null when the strategy does not set it.", key),
+		}
+	}
+	return attributes
+}
+
+func scalingStrategyDataSourceAttrTypes() map[string]attr.Type {
+	attrTypes := map[string]attr.Type{
+		"type": types.StringType,
+	}
+	for key := range scalingConfigAPIKeys {
+		attrTypes[key] = types.Int64Type
+	}
+	return attrTypes
+}
+
+// scalingStrategyDataSourceValue flattens the untyped API config map into typed attributes.
+func scalingStrategyDataSourceValue(strategy *sevallaapi.ScalingStrategy) types.Object {
+	attrTypes := scalingStrategyDataSourceAttrTypes()
+	if strategy == nil {
+		return types.ObjectNull(attrTypes)
+	}
+
+	values := map[string]attr.Value{
+		"type": types.StringValue(strategy.Type),
+	}
+	for key, apiKey := range scalingConfigAPIKeys {
+		if value, ok := scalingConfigInt64(strategy.Config[apiKey]); ok {
+			values[key] = types.Int64Value(value)
+		} else {
+			values[key] = types.Int64Null()
+		}
+	}
+
+	strategyValue, _ := types.ObjectValue(attrTypes, values)
+	return strategyValue
+}
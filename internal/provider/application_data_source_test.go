@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestApplicationDataSourceRead_CompanyID(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	d := &ApplicationDataSource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	read := func(companyID interface{}) *datasource.ReadResponse {
+		values := testNullValues(objectType)
+		values["id"] = tftypes.NewValue(tftypes.String, sevallaapitest.ApplicationID)
+		values["company_id"] = tftypes.NewValue(tftypes.String, companyID)
+
+		req := datasource.ReadRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}
+		resp := &datasource.ReadResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		d.Read(ctx, req, resp)
+		return resp
+	}
+
+	for name, companyID := range map[string]interface{}{"unset": nil, "matching": sevallaapitest.CompanyID} {
+		t.Run(name, func(t *testing.T) {
+			resp := read(companyID)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data ApplicationDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if data.CompanyID.ValueString() != sevallaapitest.CompanyID {
+				t.Errorf("expected company_id %s, got %s", sevallaapitest.CompanyID, data.CompanyID)
+			}
+		})
+	}
+
+	t.Run("other company", func(t *testing.T) {
+		resp := read("company-2")
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Application Company Mismatch" {
+			t.Errorf("expected a company mismatch error, got %v", resp.Diagnostics)
+		}
+	})
+}
+
+func TestScalingStrategyDataSourceValue(t *testing.T) {
+	if got := scalingStrategyDataSourceValue(nil); !got.IsNull() {
+		t.Errorf("expected a null object for a process without a scaling strategy, got %s", got)
+	}
+
+	got := scalingStrategyDataSourceValue(&sevallaapi.ScalingStrategy{
+		Type: "horizontal",
+		Config: map[string]interface{}{
+			"minInstanceCount": float64(2),
+			"maxInstanceCount": float64(10),
+			"targetCpuPercent": float64(75),
+		},
+	})
+
+	attrs := got.Attributes()
+	expected := map[string]types.Int64{
+		"min_instances":  types.Int64Value(2),
+		"max_instances":  types.Int64Value(10),
+		"target_cpu":     types.Int64Value(75),
+		"instance_count": types.Int64Null(),
+	}
+	for key, want := range expected {
+		if !attrs[key].Equal(want) {
+			t.Errorf("expected %s to be %s, got %s", key, want, attrs[key])
+		}
+	}
+	if !attrs["type"].Equal(types.StringValue("horizontal")) {
+		t.Errorf("unexpected type %s", attrs["type"])
+	}
+}
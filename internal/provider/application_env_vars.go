@@ -0,0 +1,396 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// Properties of a database that an environment variable can be sourced from.
+const (
+	databaseEnvInternalURL = "internal_url"
+	databaseEnvExternalURL = "external_url"
+	databaseEnvHost        = "host"
+	databaseEnvPort        = "port"
+	databaseEnvUser        = "user"
+	databaseEnvPassword    = "password"
+	databaseEnvName        = "name"
+)
+
+var databaseEnvProperties = []string{
+	databaseEnvInternalURL,
+	databaseEnvExternalURL,
+	databaseEnvHost,
+	databaseEnvPort,
+	databaseEnvUser,
+	databaseEnvPassword,
+	databaseEnvName,
+}
+
+// environmentVariableAttrTypes describes the object type of an environment variable in state.
+var environmentVariableAttrTypes = map[string]attr.Type{
+	"key":                    types.StringType,
+	"value":                  types.StringType,
+	"from_database":          types.StringType,
+	"from_database_property": types.StringType,
+}
+
+// databaseURLSchemes maps database types to the scheme used in connection URLs.
+var databaseURLSchemes = map[string]string{
+	string(sevallaapi.DatabaseTypePostgreSQL): "postgresql",
+	string(sevallaapi.DatabaseTypeMySQL):      "mysql",
+	string(sevallaapi.DatabaseTypeMariaDB):    "mysql",
+	string(sevallaapi.DatabaseTypeRedis):      "redis",
+}
+
+// validateEnvVarSources reports environment variables that do not set exactly one of value or from_database.
+func validateEnvVarSources(ctx context.Context, envVars types.List, attrPath path.Path, diags *diag.Diagnostics) {
+	if envVars.IsNull() || envVars.IsUnknown() {
+		return
+	}
+
+	var envVarModels []EnvironmentVariableModel
+	diags.Append(envVars.ElementsAs(ctx, &envVarModels, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	for i, envVar := range envVarModels {
+		// References to resources that are not created yet are checked again at apply time
+		if envVar.Value.IsUnknown() || envVar.FromDatabase.IsUnknown() {
+			continue
+		}
+
+		entryPath := attrPath.AtListIndex(i)
+		switch {
+		case envVar.Value.IsNull() && envVar.FromDatabase.IsNull():
+			diags.AddAttributeError(
+				entryPath,
+				"Missing Environment Variable Value",
+				fmt.Sprintf("The environment variable %q must set either value or from_database.", envVar.Key.ValueString()),
+			)
+		case !envVar.Value.IsNull() && !envVar.FromDatabase.IsNull():
+			diags.AddAttributeError(
+				entryPath.AtName("from_database"),
+				"Conflicting Environment Variable Value",
+				fmt.Sprintf("The environment variable %q sets both value and from_database; set only one.", envVar.Key.ValueString()),
+			)
+		case !envVar.FromDatabaseProperty.IsNull() && envVar.FromDatabase.IsNull():
+			diags.AddAttributeError(
+				entryPath.AtName("from_database_property"),
+				"Missing Database Reference",
+				fmt.Sprintf("The environment variable %q sets from_database_property without from_database.", envVar.Key.ValueString()),
+			)
+		}
+	}
+}
+
+// expandEnvironmentVariables converts planned environment variables for the API,
+// resolving values sourced from databases. Each referenced database is read once.
+// Databases cannot reference applications, so resolution cannot form a cycle.
+func (r *ApplicationResource) expandEnvironmentVariables(
+	ctx context.Context,
+	envVars types.List,
+	attrPath path.Path,
+	diags *diag.Diagnostics,
+) []sevallaapi.EnvVar {
+	var envVarModels []EnvironmentVariableModel
+	diags.Append(envVars.ElementsAs(ctx, &envVarModels, false)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	databases := make(map[string]*sevallaapi.DatabaseDetails)
+	result := make([]sevallaapi.EnvVar, 0, len(envVarModels))
+	for i, envVar := range envVarModels {
+		entryPath := attrPath.AtListIndex(i)
+
+		if envVar.FromDatabase.IsNull() || envVar.FromDatabase.IsUnknown() {
+			result = append(result, sevallaapi.EnvVar{
+				Key:   envVar.Key.ValueString(),
+				Value: envVar.Value.ValueString(),
+			})
+			continue
+		}
+
+		databaseID := envVar.FromDatabase.ValueString()
+		db, ok := databases[databaseID]
+		if !ok {
+			response, err := r.client.Databases.Get(ctx, databaseID)
+			var apiErr *sevallaapi.APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				diags.AddAttributeError(
+					entryPath.AtName("from_database"),
+					"Unresolved Database Reference",
+					fmt.Sprintf("The environment variable %q references database %q, which does not exist.",
+						envVar.Key.ValueString(), databaseID),
+				)
+				continue
+			}
+			if err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to read database %s, got error: %s", databaseID, err))
+				return nil
+			}
+			db = &response.Database
+			databases[databaseID] = db
+		}
+
+		property := databaseEnvInternalURL
+		if !envVar.FromDatabaseProperty.IsNull() {
+			property = envVar.FromDatabaseProperty.ValueString()
+		}
+
+		value, err := databaseEnvValue(db, property)
+		if err != nil {
+			diags.AddAttributeError(
+				entryPath.AtName("from_database_property"),
+				"Unresolved Database Reference",
+				fmt.Sprintf("Unable to resolve the environment variable %q from database %q: %s",
+					envVar.Key.ValueString(), databaseID, err),
+			)
+			continue
+		}
+
+		result = append(result, sevallaapi.EnvVar{
+			Key:   envVar.Key.ValueString(),
+			Value: value,
+		})
+	}
+
+	return result
+}
+
+// databaseEnvValue returns the given connection property of a database.
+func databaseEnvValue(db *sevallaapi.DatabaseDetails, property string) (string, error) {
+	switch property {
+	case databaseEnvInternalURL:
+		if db.InternalHostname == nil || db.InternalPort == nil {
+			return "", fmt.Errorf("the database has no internal hostname yet")
+		}
+		return databaseInternalURL(db)
+	case databaseEnvExternalURL:
+		if db.ExternalConnectionString == "" {
+			return "", fmt.Errorf("the database has no external connection string; enable external access first")
+		}
+		return db.ExternalConnectionString, nil
+	case databaseEnvHost:
+		if db.InternalHostname == nil {
+			return "", fmt.Errorf("the database has no internal hostname yet")
+		}
+		return *db.InternalHostname, nil
+	case databaseEnvPort:
+		if db.InternalPort == nil {
+			return "", fmt.Errorf("the database has no internal port yet")
+		}
+		return *db.InternalPort, nil
+	case databaseEnvUser:
+		if db.Data.DBUser == nil {
+			return "", fmt.Errorf("the database has no user")
+		}
+		return *db.Data.DBUser, nil
+	case databaseEnvPassword:
+		return db.Data.DBPassword, nil
+	case databaseEnvName:
+		return db.Data.DBName, nil
+	default:
+		return "", fmt.Errorf("unknown property %q", property)
+	}
+}
+
+// databaseInternalURL builds the connection URL applications in the same cluster use to reach the database.
+func databaseInternalURL(db *sevallaapi.DatabaseDetails) (string, error) {
+	var user string
+	if db.Data.DBUser != nil {
+		user = *db.Data.DBUser
+	}
+	return databaseURL(db.Type, user, db.Data.DBPassword, *db.InternalHostname, *db.InternalPort, db.Data.DBName)
+}
+
+// databaseURL builds a connection URL for a database of the given type. The user and password
+// are left out when empty, and Redis URLs never include the database name.
+func databaseURL(dbType, user, password, host, port, name string) (string, error) {
+	scheme, ok := databaseURLSchemes[dbType]
+	if !ok {
+		return "", fmt.Errorf("connection URLs are not supported for %q databases", dbType)
+	}
+
+	connURL := url.URL{
+		Scheme: scheme,
+		Host:   net.JoinHostPort(host, port),
+	}
+	if user != "" || password != "" {
+		connURL.User = url.UserPassword(user, password)
+	}
+	// Redis selects databases by number, so the name is not part of its URL
+	if dbType != string(sevallaapi.DatabaseTypeRedis) && name != "" {
+		connURL.Path = "/" + name
+	}
+
+	return connURL.String(), nil
+}
+
+// envVarSources returns the database references of the given environment variables by key,
+// so they survive mapping the API response, which only contains the resolved values.
+func envVarSources(ctx context.Context, envVars types.List) map[string]EnvironmentVariableModel {
+	sources := make(map[string]EnvironmentVariableModel)
+	if envVars.IsNull() || envVars.IsUnknown() {
+		return sources
+	}
+
+	var envVarModels []EnvironmentVariableModel
+	if diags := envVars.ElementsAs(ctx, &envVarModels, false); diags.HasError() {
+		return sources
+	}
+	for _, envVar := range envVarModels {
+		if !envVar.FromDatabase.IsNull() {
+			sources[envVar.Key.ValueString()] = envVar
+		}
+	}
+	return sources
+}
+
+// envVarKeySet returns the keys of the given environment variables.
+func envVarKeySet(ctx context.Context, envVars types.List) map[string]bool {
+	keys := make(map[string]bool)
+	if envVars.IsNull() || envVars.IsUnknown() {
+		return keys
+	}
+
+	var envVarModels []EnvironmentVariableModel
+	if diags := envVars.ElementsAs(ctx, &envVarModels, false); diags.HasError() {
+		return keys
+	}
+	for _, envVar := range envVarModels {
+		keys[envVar.Key.ValueString()] = true
+	}
+	return keys
+}
+
+// orderEnvVars orders environment variables returned by the API like the ones in prior,
+// matching them by key, so the API's ordering never shows up as a diff. Variables not in
+// prior, such as ones added outside Terraform, follow in key order.
+func orderEnvVars(ctx context.Context, prior types.List, envVars []sevallaapi.EnvVar) []sevallaapi.EnvVar {
+	position := make(map[string]int)
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var envVarModels []EnvironmentVariableModel
+		if diags := prior.ElementsAs(ctx, &envVarModels, false); !diags.HasError() {
+			for i, envVar := range envVarModels {
+				position[envVar.Key.ValueString()] = i
+			}
+		}
+	}
+
+	ordered := make([]sevallaapi.EnvVar, len(envVars))
+	copy(ordered, envVars)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		pi, iKnown := position[ordered[i].Key]
+		pj, jKnown := position[ordered[j].Key]
+		switch {
+		case iKnown && jKnown:
+			return pi < pj
+		case iKnown != jKnown:
+			return iKnown
+		default:
+			return ordered[i].Key < ordered[j].Key
+		}
+	})
+	return ordered
+}
+
+// mergeEnvironmentFrom merges the sets of environment_from into one, with later sets
+// overriding the keys of earlier ones.
+func mergeEnvironmentFrom(ctx context.Context, from types.List, diags *diag.Diagnostics) map[string]string {
+	merged := make(map[string]string)
+	if from.IsNull() || from.IsUnknown() {
+		return merged
+	}
+
+	var sets []map[string]string
+	diags.Append(from.ElementsAs(ctx, &sets, false)...)
+	for _, set := range sets {
+		for key, value := range set {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// withEnvironmentFrom adds the variables merged from environment_from to envVars. Variables
+// already in envVars take precedence; the merged ones follow, sorted by key so the request
+// does not depend on map ordering.
+func withEnvironmentFrom(envVars []sevallaapi.EnvVar, merged map[string]string) []sevallaapi.EnvVar {
+	explicit := make(map[string]bool, len(envVars))
+	for _, envVar := range envVars {
+		explicit[envVar.Key] = true
+	}
+
+	keys := make([]string, 0, len(merged))
+	for key := range merged {
+		if !explicit[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	result := make([]sevallaapi.EnvVar, 0, len(envVars)+len(keys))
+	result = append(result, envVars...)
+	for _, key := range keys {
+		result = append(result, sevallaapi.EnvVar{Key: key, Value: merged[key]})
+	}
+	return result
+}
+
+// refreshEnvironmentFrom updates environment_from with the values the API returned for the
+// variables it provides, and drops the ones removed outside Terraform, so either shows up as
+// a diff. A variable is provided by the last set that defines it, unless explicit overrides
+// it. The provided keys are returned so they are left out of environment_variables.
+func refreshEnvironmentFrom(
+	ctx context.Context,
+	from types.List,
+	explicit map[string]bool,
+	actual map[string]string,
+) (types.List, map[string]bool) {
+	provided := make(map[string]bool)
+	if from.IsNull() || from.IsUnknown() {
+		return from, provided
+	}
+
+	var sets []map[string]string
+	if diags := from.ElementsAs(ctx, &sets, false); diags.HasError() {
+		return from, provided
+	}
+
+	providedBy := make(map[string]int)
+	for i, set := range sets {
+		for key := range set {
+			providedBy[key] = i
+		}
+	}
+	for key, i := range providedBy {
+		if explicit[key] {
+			continue
+		}
+		provided[key] = true
+		if value, ok := actual[key]; ok {
+			sets[i][key] = value
+		} else {
+			delete(sets[i], key)
+		}
+	}
+
+	refreshed, diags := types.ListValueFrom(ctx, types.MapType{ElemType: types.StringType}, sets)
+	if diags.HasError() {
+		return from, provided
+	}
+	return refreshed, provided
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ApplicationHTTPRequestsDataSource{}
+
+func NewApplicationHTTPRequestsDataSource() datasource.DataSource {
+	return &ApplicationHTTPRequestsDataSource{}
+}
+
+// ApplicationHTTPRequestsDataSource defines the data source implementation.
+type ApplicationHTTPRequestsDataSource struct {
+	client *sevallaapi.Client
+}
+
+// ApplicationHTTPRequestsDataSourceModel describes the data source data model.
+type ApplicationHTTPRequestsDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	StartDate types.String `tfsdk:"start_date"`
+	EndDate   types.String `tfsdk:"end_date"`
+	Interval  types.String `tfsdk:"interval"`
+	Timeframe types.List   `tfsdk:"timeframe"`
+	Data      types.List   `tfsdk:"data"`
+}
+
+func (d *ApplicationHTTPRequestsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_http_requests"
+}
+
+func (d *ApplicationHTTPRequestsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The ID of the application.",
+		},
+	}
+	maps.Copy(attributes, metricsQueryAttributes())
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the number of HTTP requests a Sevalla application served in each interval of a timeframe.",
+		Attributes:          attributes,
+	}
+}
+
+func (d *ApplicationHTTPRequestsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(SevallaProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected SevallaProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *ApplicationHTTPRequestsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ApplicationHTTPRequestsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metrics, err := d.client.Applications.GetHTTPRequestMetrics(ctx, data.ID.ValueString(),
+		newMetricsQuery(data.StartDate, data.EndDate, data.Interval))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read application HTTP request metrics, got error: %s", err))
+		return
+	}
+
+	data.Timeframe, data.Data = flattenMetricsSeries(ctx, metrics.Timeframe, metrics.Data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestApplicationHTTPRequestsDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	d := &ApplicationHTTPRequestsDataSource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	read := func() (ApplicationHTTPRequestsDataSourceModel, *datasource.ReadResponse) {
+		values := testNullValues(objectType)
+		values["id"] = tftypes.NewValue(tftypes.String, sevallaapitest.ApplicationID)
+		values["start_date"] = tftypes.NewValue(tftypes.String, "2024-01-01")
+		values["end_date"] = tftypes.NewValue(tftypes.String, "2024-01-02")
+		values["interval"] = tftypes.NewValue(tftypes.String, "day")
+
+		req := datasource.ReadRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}
+		resp := &datasource.ReadResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		d.Read(ctx, req, resp)
+
+		var data ApplicationHTTPRequestsDataSourceModel
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		}
+		return data, resp
+	}
+
+	t.Run("series", func(t *testing.T) {
+		data, resp := read()
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var timeframe []string
+		var values []float64
+		resp.Diagnostics.Append(data.Timeframe.ElementsAs(ctx, &timeframe, false)...)
+		resp.Diagnostics.Append(data.Data.ElementsAs(ctx, &values, false)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if len(timeframe) != 2 || timeframe[0] != "2024-01-01T00:00:00Z" {
+			t.Errorf("unexpected timeframe %v", timeframe)
+		}
+		if len(values) != 2 || values[0] != 120 || values[1] != 95.5 {
+			t.Errorf("unexpected data %v", values)
+		}
+	})
+
+	t.Run("empty series", func(t *testing.T) {
+		server.HandleJSON(http.MethodGet, "/applications/{id}/metrics/http-requests", http.StatusOK,
+			`{"app": {"id": "app-1", "metrics": {"http_requests": []}}}`)
+
+		data, resp := read()
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if data.Data.IsNull() || len(data.Data.Elements()) != 0 || data.Timeframe.IsNull() {
+			t.Errorf("expected empty lists, got %s and %s", data.Timeframe, data.Data)
+		}
+	})
+}
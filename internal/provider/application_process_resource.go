@@ -0,0 +1,547 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ApplicationProcessResource{}
+var _ resource.ResourceWithImportState = &ApplicationProcessResource{}
+var _ resource.ResourceWithValidateConfig = &ApplicationProcessResource{}
+
+const (
+	scalingStrategyManual     = "manual"
+	scalingStrategyHorizontal = "horizontal"
+)
+
+// scalingConfigAPIKeys maps the snake_case config keys used in Terraform to the
+// camelCase keys the API expects in ScalingStrategy.Config.
+var scalingConfigAPIKeys = map[string]string{
+	"instance_count":              "instanceCount",
+	"min_instances":               "minInstanceCount",
+	"max_instances":               "maxInstanceCount",
+	"target_cpu":                  "targetCpuPercent",
+	"target_memory":               "targetMemoryPercent",
+	"scale_up_interval_seconds":   "scaleUpIntervalSeconds",
+	"scale_up_increment":          "scaleUpIncrement",
+	"scale_down_interval_seconds": "scaleDownIntervalSeconds",
+	"scale_down_increment":        "scaleDownIncrement",
+}
+
+// scalingStrategyAttrTypes describes the object type of a scaling strategy in state.
+var scalingStrategyAttrTypes = map[string]attr.Type{
+	"type":   types.StringType,
+	"config": types.MapType{ElemType: types.Int64Type},
+}
+
+func NewApplicationProcessResource() resource.Resource {
+	return &ApplicationProcessResource{}
+}
+
+// ApplicationProcessResource defines the resource implementation.
+type ApplicationProcessResource struct {
+	client *sevallaapi.Client
+}
+
+// ScalingStrategyModel represents the scaling strategy of a process.
+type ScalingStrategyModel struct {
+	Type   types.String `tfsdk:"type"`
+	Config types.Map    `tfsdk:"config"`
+}
+
+// ApplicationProcessResourceModel describes the resource data model.
+type ApplicationProcessResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	AppID            types.String `tfsdk:"app_id"`
+	Key              types.String `tfsdk:"key"`
+	Type             types.String `tfsdk:"type"`
+	DisplayName      types.String `tfsdk:"display_name"`
+	ResourceTypeName types.String `tfsdk:"resource_type_name"`
+	Entrypoint       types.String `tfsdk:"entrypoint"`
+	ScalingStrategy  types.Object `tfsdk:"scaling_strategy"`
+}
+
+func (r *ApplicationProcessResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_process"
+}
+
+func (r *ApplicationProcessResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	configKeys := make([]string, 0, len(scalingConfigAPIKeys))
+	for key := range scalingConfigAPIKeys {
+		configKeys = append(configKeys, key)
+	}
+	sort.Strings(configKeys)
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the scaling and entrypoint of a process belonging to a Sevalla application. " +
+			"Processes are created with the application, so destroying this resource only removes it from state.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier of the process.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"app_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the application the process belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The key of the process within the application, for example `web`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The type of the process.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The display name of the process.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"resource_type_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The compute tier the process runs on.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"entrypoint": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Overrides the default start command of the process.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scaling_strategy": schema.SingleNestedAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "How the process is scaled. When omitted, the current strategy is left unchanged.",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "The scaling strategy type (`manual` or `horizontal`).",
+						Validators: []validator.String{
+							stringvalidator.OneOf(scalingStrategyManual, scalingStrategyHorizontal),
+						},
+					},
+					"config": schema.MapAttribute{
+						Required:    true,
+						ElementType: types.Int64Type,
+						MarkdownDescription: "Scaling settings. `manual` requires `instance_count`; `horizontal` requires " +
+							"`min_instances` and `max_instances`, with `min_instances` no greater than `max_instances`, " +
+							"and also accepts `target_cpu`, `target_memory`, `scale_up_interval_seconds`, " +
+							"`scale_up_increment`, `scale_down_interval_seconds` and `scale_down_increment`.",
+						Validators: []validator.Map{
+							mapvalidator.KeysAre(stringvalidator.OneOf(configKeys...)),
+							mapvalidator.ValueInt64sAre(int64validator.AtLeast(0)),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ApplicationProcessResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ApplicationProcessResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateScalingStrategy(ctx, data.ScalingStrategy, path.Root("scaling_strategy"), &resp.Diagnostics)
+}
+
+func (r *ApplicationProcessResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(SevallaProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected SevallaProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *ApplicationProcessResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ApplicationProcessResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	processID, err := r.findProcessID(ctx, data.AppID.ValueString(), data.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find application process, got error: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Adopting application process", map[string]interface{}{
+		"app_id":     data.AppID.ValueString(),
+		"key":        data.Key.ValueString(),
+		"process_id": processID,
+	})
+
+	process, diags := r.applyProcessConfig(ctx, processID, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mapProcessToModel(ctx, &data, &process.Process)
+
+	tflog.Trace(ctx, "Created application process resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApplicationProcessResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ApplicationProcessResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported processes only carry the app ID and key until the first read
+	processID := data.ID.ValueString()
+	if processID == "" {
+		var err error
+		processID, err = r.findProcessID(ctx, data.AppID.ValueString(), data.Key.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find application process, got error: %s", err))
+			return
+		}
+	}
+
+	process, err := r.client.Applications.GetProcess(ctx, processID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read application process, got error: %s", err))
+		return
+	}
+
+	mapProcessToModel(ctx, &data, &process.Process)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApplicationProcessResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ApplicationProcessResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	process, diags := r.applyProcessConfig(ctx, data.ID.ValueString(), &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mapProcessToModel(ctx, &data, &process.Process)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApplicationProcessResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ApplicationProcessResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Processes cannot be deleted on their own; they go away with the application
+	tflog.Debug(ctx, "Removing application process from state", map[string]interface{}{
+		"process_id": data.ID.ValueString(),
+	})
+}
+
+func (r *ApplicationProcessResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	appID, key, ok := strings.Cut(req.ID, "/")
+	if !ok || appID == "" || key == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: app_id/key. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), appID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), key)...)
+}
+
+// findProcessID looks up the ID of the process with the given key on an application.
+func (r *ApplicationProcessResource) findProcessID(ctx context.Context, appID, key string) (string, error) {
+	app, err := r.client.Applications.Get(ctx, appID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, process := range app.App.Processes {
+		if process.Key == key {
+			return process.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("application %s has no process with key %q", appID, key)
+}
+
+// applyProcessConfig sends the planned scaling strategy and entrypoint to the API and
+// returns the resulting process. Nothing is sent when neither is configured.
+func (r *ApplicationProcessResource) applyProcessConfig(
+	ctx context.Context,
+	processID string,
+	data *ApplicationProcessResourceModel,
+) (*sevallaapi.Process, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	updateReq := sevallaapi.UpdateProcessRequest{}
+	if !data.Entrypoint.IsNull() && !data.Entrypoint.IsUnknown() {
+		updateReq.Entrypoint = stringPointer(data.Entrypoint.ValueString())
+	}
+	updateReq.ScalingStrategy = expandScalingStrategy(ctx, data.ScalingStrategy, &diags)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var process *sevallaapi.Process
+	var err error
+	if updateReq.Entrypoint == nil && updateReq.ScalingStrategy == nil {
+		process, err = r.client.Applications.GetProcess(ctx, processID)
+	} else {
+		process, err = r.client.Applications.UpdateProcess(ctx, processID, updateReq)
+	}
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update application process, got error: %s", err))
+		return nil, diags
+	}
+
+	return process, diags
+}
+
+func mapProcessToModel(ctx context.Context, data *ApplicationProcessResourceModel, process *sevallaapi.ProcessDetails) {
+	data.ID = types.StringValue(process.ID)
+	data.Type = types.StringValue(process.Type)
+	data.DisplayName = types.StringValue(process.DisplayName)
+	data.ResourceTypeName = types.StringValue(process.ResourceTypeName)
+	data.Entrypoint = types.StringValue(process.Entrypoint)
+	data.ScalingStrategy = flattenScalingStrategy(ctx, process.ScalingStrategy, data.ScalingStrategy)
+}
+
+// validateScalingStrategy checks that the config keys match the strategy type and that
+// min_instances does not exceed max_instances.
+func validateScalingStrategy(ctx context.Context, strategy types.Object, attrPath path.Path, diags *diag.Diagnostics) {
+	if strategy.IsNull() || strategy.IsUnknown() {
+		return
+	}
+
+	var model ScalingStrategyModel
+	diags.Append(strategy.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() || model.Type.IsUnknown() || model.Config.IsNull() || model.Config.IsUnknown() {
+		return
+	}
+
+	configPath := attrPath.AtName("config")
+	config := model.Config.Elements()
+
+	var required, forbidden []string
+	switch model.Type.ValueString() {
+	case scalingStrategyManual:
+		required = []string{"instance_count"}
+		for key := range scalingConfigAPIKeys {
+			if key != "instance_count" {
+				forbidden = append(forbidden, key)
+			}
+		}
+	case scalingStrategyHorizontal:
+		required = []string{"min_instances", "max_instances"}
+		forbidden = []string{"instance_count"}
+	default:
+		return
+	}
+
+	for _, key := range required {
+		if _, ok := config[key]; !ok {
+			diags.AddAttributeError(
+				configPath,
+				"Missing Scaling Config Key",
+				fmt.Sprintf("The %q scaling strategy requires the %q config key.", model.Type.ValueString(), key),
+			)
+		}
+	}
+
+	sort.Strings(forbidden)
+	for _, key := range forbidden {
+		if _, ok := config[key]; ok {
+			diags.AddAttributeError(
+				configPath.AtMapKey(key),
+				"Unsupported Scaling Config Key",
+				fmt.Sprintf("The %q config key cannot be used with the %q scaling strategy.", key, model.Type.ValueString()),
+			)
+		}
+	}
+
+	minInstances, minOK := config["min_instances"].(types.Int64)
+	maxInstances, maxOK := config["max_instances"].(types.Int64)
+	if minOK && maxOK && !minInstances.IsNull() && !minInstances.IsUnknown() && !maxInstances.IsNull() && !maxInstances.IsUnknown() &&
+		minInstances.ValueInt64() > maxInstances.ValueInt64() {
+		diags.AddAttributeError(
+			configPath.AtMapKey("min_instances"),
+			"Invalid Scaling Range",
+			fmt.Sprintf("min_instances (%d) cannot be greater than max_instances (%d).", minInstances.ValueInt64(), maxInstances.ValueInt64()),
+		)
+	}
+}
+
+// expandScalingStrategy converts the Terraform scaling strategy into the API's
+// representation, translating config keys to camelCase.
+func expandScalingStrategy(ctx context.Context, strategy types.Object, diags *diag.Diagnostics) *sevallaapi.ScalingStrategy {
+	if strategy.IsNull() || strategy.IsUnknown() {
+		return nil
+	}
+
+	var model ScalingStrategyModel
+	diags.Append(strategy.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	var config map[string]int64
+	diags.Append(model.Config.ElementsAs(ctx, &config, false)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	apiConfig := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		if apiKey, ok := scalingConfigAPIKeys[key]; ok {
+			apiConfig[apiKey] = value
+		}
+	}
+
+	return &sevallaapi.ScalingStrategy{
+		Type:   model.Type.ValueString(),
+		Config: apiConfig,
+	}
+}
+
+// flattenScalingStrategy converts the API scaling strategy into a Terraform object.
+// When the previous value has the same strategy type, only the config keys it
+// contains are kept so API-side defaults don't show up as drift.
+func flattenScalingStrategy(ctx context.Context, strategy *sevallaapi.ScalingStrategy, previous types.Object) types.Object {
+	if strategy == nil {
+		return types.ObjectNull(scalingStrategyAttrTypes)
+	}
+
+	var keep map[string]attr.Value
+	if !previous.IsNull() && !previous.IsUnknown() {
+		var model ScalingStrategyModel
+		if diags := previous.As(ctx, &model, basetypes.ObjectAsOptions{}); !diags.HasError() &&
+			model.Type.ValueString() == strategy.Type && !model.Config.IsNull() && !model.Config.IsUnknown() {
+			keep = model.Config.Elements()
+		}
+	}
+
+	config := make(map[string]attr.Value)
+	for key, apiKey := range scalingConfigAPIKeys {
+		value, ok := scalingConfigInt64(strategy.Config[apiKey])
+		if !ok {
+			continue
+		}
+		if keep != nil {
+			if _, ok := keep[key]; !ok {
+				continue
+			}
+		}
+		config[key] = types.Int64Value(value)
+	}
+
+	configValue, _ := types.MapValue(types.Int64Type, config)
+	strategyValue, _ := types.ObjectValue(scalingStrategyAttrTypes, map[string]attr.Value{
+		"type":   types.StringValue(strategy.Type),
+		"config": configValue,
+	})
+
+	return strategyValue
+}
+
+// scalingConfigInt64 normalizes a decoded JSON config value to an int64. The config is
+// decoded into interface{}, so numbers arrive as float64, and some API responses quote
+// them as strings. Values that are not whole numbers are skipped.
+func scalingConfigInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) || math.IsInf(v, 0) {
+			return 0, false
+		}
+		return int64(v), true
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case json.Number:
+		return scalingConfigInt64(v.String())
+	case string:
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return parsed, true
+		}
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		return scalingConfigInt64(parsed)
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,340 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestAccApplicationProcessResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccApplicationProcessResourceConfigManual("test-process-app", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sevalla_application_process.test", "key", "web"),
+					resource.TestCheckResourceAttr("sevalla_application_process.test", "scaling_strategy.type", "manual"),
+					resource.TestCheckResourceAttr("sevalla_application_process.test", "scaling_strategy.config.instance_count", "1"),
+					resource.TestCheckResourceAttrSet("sevalla_application_process.test", "id"),
+					resource.TestCheckResourceAttrSet("sevalla_application_process.test", "resource_type_name"),
+				),
+			},
+			// Update to horizontal scaling
+			{
+				Config: testAccApplicationProcessResourceConfigHorizontal("test-process-app", 1, 3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sevalla_application_process.test", "scaling_strategy.type", "horizontal"),
+					resource.TestCheckResourceAttr("sevalla_application_process.test", "scaling_strategy.config.min_instances", "1"),
+					resource.TestCheckResourceAttr("sevalla_application_process.test", "scaling_strategy.config.max_instances", "3"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccApplicationProcessResourceConfigManual(name string, instances int) string {
+	return testAccApplicationResourceConfig(name) + fmt.Sprintf(`
+resource "sevalla_application_process" "test" {
+  app_id = sevalla_application.test.id
+  key    = "web"
+
+  scaling_strategy = {
+    type = "manual"
+    config = {
+      instance_count = %[1]d
+    }
+  }
+}
+`, instances)
+}
+
+func testAccApplicationProcessResourceConfigHorizontal(name string, minInstances, maxInstances int) string {
+	return testAccApplicationResourceConfig(name) + fmt.Sprintf(`
+resource "sevalla_application_process" "test" {
+  app_id = sevalla_application.test.id
+  key    = "web"
+
+  scaling_strategy = {
+    type = "horizontal"
+    config = {
+      min_instances = %[1]d
+      max_instances = %[2]d
+    }
+  }
+}
+`, minInstances, maxInstances)
+}
+
+func testScalingStrategyObject(t *testing.T, strategyType string, config map[string]int64) types.Object {
+	t.Helper()
+
+	values := make(map[string]attr.Value, len(config))
+	for key, value := range config {
+		values[key] = types.Int64Value(value)
+	}
+	configValue, diags := types.MapValue(types.Int64Type, values)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	strategy, diags := types.ObjectValue(scalingStrategyAttrTypes, map[string]attr.Value{
+		"type":   types.StringValue(strategyType),
+		"config": configValue,
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return strategy
+}
+
+func TestValidateScalingStrategy(t *testing.T) {
+	tests := map[string]struct {
+		strategyType string
+		config       map[string]int64
+		wantErrors   int
+	}{
+		"manual":                   {"manual", map[string]int64{"instance_count": 2}, 0},
+		"manual missing count":     {"manual", map[string]int64{}, 1},
+		"manual with min":          {"manual", map[string]int64{"instance_count": 2, "min_instances": 1}, 1},
+		"horizontal":               {"horizontal", map[string]int64{"min_instances": 1, "max_instances": 3, "target_cpu": 70}, 0},
+		"horizontal missing max":   {"horizontal", map[string]int64{"min_instances": 1}, 1},
+		"horizontal with count":    {"horizontal", map[string]int64{"min_instances": 1, "max_instances": 3, "instance_count": 2}, 1},
+		"horizontal missing both":  {"horizontal", map[string]int64{}, 2},
+		"horizontal min over max":  {"horizontal", map[string]int64{"min_instances": 4, "max_instances": 3}, 1},
+		"horizontal min equal max": {"horizontal", map[string]int64{"min_instances": 3, "max_instances": 3}, 0},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateScalingStrategy(context.Background(), testScalingStrategyObject(t, tt.strategyType, tt.config), path.Root("scaling_strategy"), &diags)
+			if got := diags.ErrorsCount(); got != tt.wantErrors {
+				t.Errorf("expected %d errors, got %d: %v", tt.wantErrors, got, diags)
+			}
+		})
+	}
+}
+
+func TestScalingStrategyRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	planned := testScalingStrategyObject(t, "horizontal", map[string]int64{"min_instances": 2, "max_instances": 5})
+
+	var diags diag.Diagnostics
+	expanded := expandScalingStrategy(ctx, planned, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if expanded.Config["minInstanceCount"] != int64(2) || expanded.Config["maxInstanceCount"] != int64(5) {
+		t.Fatalf("unexpected API config: %v", expanded.Config)
+	}
+
+	// JSON numbers decode as float64, and the API fills in defaults for unset keys
+	fromAPI := &sevallaapi.ScalingStrategy{
+		Type: "horizontal",
+		Config: map[string]interface{}{
+			"minInstanceCount": float64(2),
+			"maxInstanceCount": float64(5),
+			"targetCpuPercent": float64(80),
+		},
+	}
+
+	flattened := flattenScalingStrategy(ctx, fromAPI, planned)
+	if !flattened.Equal(planned) {
+		t.Errorf("expected %s, got %s", planned, flattened)
+	}
+
+	imported := flattenScalingStrategy(ctx, fromAPI, types.ObjectNull(scalingStrategyAttrTypes))
+	var model ScalingStrategyModel
+	diags.Append(imported.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if len(model.Config.Elements()) != 3 {
+		t.Errorf("expected all API config keys without a previous value, got %v", model.Config)
+	}
+}
+
+func TestApplicationProcessResourceUpdate_HorizontalScaling(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	server.HandleJSON(http.MethodGet, "/applications/processes/{id}", http.StatusOK, `{
+  "process": {
+    "id": "proc-1",
+    "type": "web",
+    "display_name": "Web process",
+    "scaling_strategy": {
+      "type": "horizontal",
+      "config": {"minInstanceCount": 2, "maxInstanceCount": 5, "targetCpuPercent": 70, "scaleUpIncrement": 1}
+    },
+    "resource_type_name": "s1",
+    "entrypoint": "npm start"
+  }
+}`)
+	r := &ApplicationProcessResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := testNullValues(objectType)
+	values["id"] = tftypes.NewValue(tftypes.String, sevallaapitest.ProcessID)
+	values["app_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.ApplicationID)
+	values["key"] = tftypes.NewValue(tftypes.String, "web")
+	strategyType := objectType.AttributeTypes["scaling_strategy"].(tftypes.Object)
+	configType := strategyType.AttributeTypes["config"]
+	values["scaling_strategy"] = tftypes.NewValue(strategyType, map[string]tftypes.Value{
+		"type": tftypes.NewValue(tftypes.String, "horizontal"),
+		"config": tftypes.NewValue(configType, map[string]tftypes.Value{
+			"min_instances": tftypes.NewValue(tftypes.Number, 2),
+			"max_instances": tftypes.NewValue(tftypes.Number, 5),
+			"target_cpu":    tftypes.NewValue(tftypes.Number, 70),
+		}),
+	})
+	plan := tftypes.NewValue(objectType, values)
+
+	resp := &fwresource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Update(ctx, fwresource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: plan},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	// Config values must be sent as JSON numbers, not strings
+	var req sevallaapitest.Request
+	for _, sent := range server.Requests() {
+		if sent.Method == http.MethodPut {
+			req = sent
+		}
+	}
+	var body struct {
+		ScalingStrategy struct {
+			Type   string                     `json:"type"`
+			Config map[string]json.RawMessage `json:"config"`
+		} `json:"scaling_strategy"`
+	}
+	if err := json.Unmarshal(req.Body, &body); err != nil {
+		t.Fatalf("unable to decode request body: %s", err)
+	}
+	for key, want := range map[string]string{"minInstanceCount": "2", "maxInstanceCount": "5", "targetCpuPercent": "70"} {
+		if got := string(body.ScalingStrategy.Config[key]); got != want {
+			t.Errorf("expected %s to be sent as %s, got %s", key, want, got)
+		}
+	}
+	if body.ScalingStrategy.Type != "horizontal" || len(body.ScalingStrategy.Config) != 3 {
+		t.Errorf("unexpected scaling strategy %s", req.Body)
+	}
+
+	// The state written back must equal the plan, without the API's extra keys
+	var planned, data ApplicationProcessResourceModel
+	resp.Diagnostics.Append(tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan}.Get(ctx, &planned)...)
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if !data.ScalingStrategy.Equal(planned.ScalingStrategy) {
+		t.Errorf("expected scaling strategy %s, got %s", planned.ScalingStrategy, data.ScalingStrategy)
+	}
+}
+
+func TestApplicationProcessResourceRead_NoDrift(t *testing.T) {
+	ctx := context.Background()
+
+	// The API reports whole numbers as floats or quoted strings and adds defaults for unset keys
+	server := sevallaapitest.NewServer(t)
+	server.HandleJSON(http.MethodGet, "/applications/processes/{id}", http.StatusOK, `{
+  "process": {
+    "id": "proc-1",
+    "type": "web",
+    "display_name": "Web process",
+    "scaling_strategy": {
+      "type": "horizontal",
+      "config": {"minInstanceCount": 2.0, "maxInstanceCount": "5", "targetCpuPercent": 70, "scaleDownIncrement": 1}
+    },
+    "resource_type_name": "s1",
+    "entrypoint": "npm start"
+  }
+}`)
+	r := &ApplicationProcessResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	strategyType := objectType.AttributeTypes["scaling_strategy"].(tftypes.Object)
+
+	prior := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":                 tftypes.NewValue(tftypes.String, sevallaapitest.ProcessID),
+		"app_id":             tftypes.NewValue(tftypes.String, sevallaapitest.ApplicationID),
+		"key":                tftypes.NewValue(tftypes.String, "web"),
+		"type":               tftypes.NewValue(tftypes.String, "web"),
+		"display_name":       tftypes.NewValue(tftypes.String, "Web process"),
+		"resource_type_name": tftypes.NewValue(tftypes.String, "s1"),
+		"entrypoint":         tftypes.NewValue(tftypes.String, "npm start"),
+		"scaling_strategy": tftypes.NewValue(strategyType, map[string]tftypes.Value{
+			"type": tftypes.NewValue(tftypes.String, "horizontal"),
+			"config": tftypes.NewValue(strategyType.AttributeTypes["config"], map[string]tftypes.Value{
+				"min_instances": tftypes.NewValue(tftypes.Number, 2),
+				"max_instances": tftypes.NewValue(tftypes.Number, 5),
+				"target_cpu":    tftypes.NewValue(tftypes.Number, 70),
+			}),
+		}),
+	})
+
+	resp := &fwresource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: prior},
+	}
+	r.Read(ctx, fwresource.ReadRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: prior}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	// A refresh that leaves state untouched means the next plan is empty
+	if !resp.State.Raw.Equal(prior) {
+		t.Errorf("expected no drift after refresh, got %s", resp.State.Raw)
+	}
+}
+
+func TestScalingConfigInt64(t *testing.T) {
+	tests := map[string]struct {
+		value  interface{}
+		want   int64
+		wantOK bool
+	}{
+		"float":            {float64(3), 3, true},
+		"fractional float": {2.5, 0, false},
+		"int":              {4, 4, true},
+		"json number":      {json.Number("6"), 6, true},
+		"string":           {"7", 7, true},
+		"float string":     {"8.0", 8, true},
+		"invalid string":   {"many", 0, false},
+		"bool":             {true, 0, false},
+		"nil":              {nil, 0, false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := scalingConfigInt64(tt.value)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("expected (%d, %t), got (%d, %t)", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
@@ -2,20 +2,31 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
 )
@@ -23,6 +34,19 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ApplicationResource{}
 var _ resource.ResourceWithImportState = &ApplicationResource{}
+var _ resource.ResourceWithValidateConfig = &ApplicationResource{}
+
+const (
+	defaultApplicationCreateTimeout = 15 * time.Minute
+	defaultApplicationUpdateTimeout = 15 * time.Minute
+	defaultApplicationDeleteTimeout = 10 * time.Minute
+)
+
+// applicationPollInterval is how often application status is polled. Tests shorten it.
+var applicationPollInterval = 5 * time.Second
+
+// errApplicationFailed is returned when an application reaches the failed status while waiting.
+var errApplicationFailed = errors.New("application reached failed status")
 
 func NewApplicationResource() resource.Resource {
 	return &ApplicationResource{}
@@ -30,13 +54,17 @@ func NewApplicationResource() resource.Resource {
 
 // ApplicationResource defines the resource implementation.
 type ApplicationResource struct {
-	client *sevallaapi.Client
+	client             *sevallaapi.Client
+	companyID          string
+	conditionalUpdates bool
 }
 
 // EnvironmentVariableModel represents an environment variable.
 type EnvironmentVariableModel struct {
-	Key   types.String `tfsdk:"key"`
-	Value types.String `tfsdk:"value"`
+	Key                  types.String `tfsdk:"key"`
+	Value                types.String `tfsdk:"value"`
+	FromDatabase         types.String `tfsdk:"from_database"`
+	FromDatabaseProperty types.String `tfsdk:"from_database_property"`
 }
 
 // DeploymentModel represents a deployment.
@@ -70,29 +98,70 @@ type InternalConnectionModel struct {
 	CreatedAt  types.Int64  `tfsdk:"created_at"`
 }
 
+// PackConfigModel represents the pack_config attribute.
+type PackConfigModel struct {
+	Builder types.String `tfsdk:"builder"`
+}
+
 // ApplicationResourceModel describes the resource data model.
 type ApplicationResourceModel struct {
-	ID                   types.String `tfsdk:"id"`
-	Name                 types.String `tfsdk:"name"`
-	DisplayName          types.String `tfsdk:"display_name"`
-	Status               types.String `tfsdk:"status"`
-	CompanyID            types.String `tfsdk:"company_id"`
-	RepoURL              types.String `tfsdk:"repo_url"`
-	DefaultBranch        types.String `tfsdk:"default_branch"`
-	AutoDeploy           types.Bool   `tfsdk:"auto_deploy"`
-	BuildPath            types.String `tfsdk:"build_path"`
-	BuildType            types.String `tfsdk:"build_type"`
-	NodeVersion          types.String `tfsdk:"node_version"`
-	DockerfilePath       types.String `tfsdk:"dockerfile_path"`
-	DockerComposeFile    types.String `tfsdk:"docker_compose_file"`
-	StartCommand         types.String `tfsdk:"start_command"`
-	InstallCommand       types.String `tfsdk:"install_command"`
-	EnvironmentVariables types.List   `tfsdk:"environment_variables"`
-	CreatedAt            types.Int64  `tfsdk:"created_at"`
-	UpdatedAt            types.Int64  `tfsdk:"updated_at"`
-	Deployments          types.List   `tfsdk:"deployments"`
-	Processes            types.List   `tfsdk:"processes"`
-	InternalConnections  types.List   `tfsdk:"internal_connections"`
+	ID                   types.String   `tfsdk:"id"`
+	Name                 types.String   `tfsdk:"name"`
+	DisplayName          types.String   `tfsdk:"display_name"`
+	Status               types.String   `tfsdk:"status"`
+	CompanyID            types.String   `tfsdk:"company_id"`
+	RepoURL              types.String   `tfsdk:"repo_url"`
+	DefaultBranch        types.String   `tfsdk:"default_branch"`
+	AutoDeploy           types.Bool     `tfsdk:"auto_deploy"`
+	BuildPath            types.String   `tfsdk:"build_path"`
+	BuildType            types.String   `tfsdk:"build_type"`
+	NodeVersion          types.String   `tfsdk:"node_version"`
+	DockerfilePath       types.String   `tfsdk:"dockerfile_path"`
+	DockerComposeFile    types.String   `tfsdk:"docker_compose_file"`
+	PackConfig           types.Object   `tfsdk:"pack_config"`
+	StartCommand         types.String   `tfsdk:"start_command"`
+	InstallCommand       types.String   `tfsdk:"install_command"`
+	EnvironmentVariables types.List     `tfsdk:"environment_variables"`
+	EnvironmentFrom      types.List     `tfsdk:"environment_from"`
+	Domain               types.String   `tfsdk:"domain"`
+	URL                  types.String   `tfsdk:"url"`
+	CreatedAt            types.Int64    `tfsdk:"created_at"`
+	UpdatedAt            types.Int64    `tfsdk:"updated_at"`
+	DeploymentsLimit     types.Int64    `tfsdk:"deployments_limit"`
+	LatestDeployment     types.Object   `tfsdk:"latest_deployment"`
+	Deployments          types.List     `tfsdk:"deployments"`
+	Processes            types.List     `tfsdk:"processes"`
+	InternalConnections  types.List     `tfsdk:"internal_connections"`
+	RestartTrigger       types.String   `tfsdk:"restart_trigger"`
+	WaitForReady         types.Bool     `tfsdk:"wait_for_ready"`
+	DeletionProtection   types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+}
+
+// deploymentAttrTypes describes the object type of a single deployment in state.
+var deploymentAttrTypes = map[string]attr.Type{
+	"id":             types.StringType,
+	"status":         types.StringType,
+	"branch":         types.StringType,
+	"repo_url":       types.StringType,
+	"commit_hash":    types.StringType,
+	"commit_message": types.StringType,
+	"created_at":     types.Int64Type,
+	"updated_at":     types.Int64Type,
+	"build_logs":     types.StringType,
+}
+
+// latestDeploymentAttrTypes describes latest_deployment, which leaves out the build logs so they
+// are not kept in state on every refresh.
+var latestDeploymentAttrTypes = map[string]attr.Type{
+	"id":             types.StringType,
+	"status":         types.StringType,
+	"branch":         types.StringType,
+	"repo_url":       types.StringType,
+	"commit_hash":    types.StringType,
+	"commit_message": types.StringType,
+	"created_at":     types.Int64Type,
+	"updated_at":     types.Int64Type,
 }
 
 func (r *ApplicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -101,7 +170,9 @@ func (r *ApplicationResource) Metadata(ctx context.Context, req resource.Metadat
 
 func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages a Sevalla application with full configuration support including repository settings, build configuration, environment variables, and deployment management.",
+		MarkdownDescription: "Manages a Sevalla application with full configuration support including repository settings, build configuration, environment variables, and deployment management. " +
+			"Import it with `application_id`, `company_id/application_id` or `company/company_id/application/application_id`; " +
+			"the last two also set `company_id` and check that the application belongs to that company.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -124,8 +195,12 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "The current status of the application (deploying, deployed, failed, stopped).",
 			},
 			"company_id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The company ID that owns this application.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The company ID that owns this application. Defaults to the provider's `company_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"repo_url": schema.StringAttribute{
 				Optional:            true,
@@ -144,21 +219,30 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "Whether to automatically deploy on git push.",
 			},
 			"build_path": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "The build path for the application.",
+				Optional: true,
+				MarkdownDescription: "The directory in the repository to build the application from, such as `apps/web` " +
+					"in a monorepo. Builds from the repository root when unset.",
 			},
 			"build_type": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "The build type (dockerfile, pack, nixpacks).",
+				MarkdownDescription: "The build type (dockerfile, pack, nixpacks). `dockerfile` requires `dockerfile_path`.",
 				Validators: []validator.String{
-					stringvalidator.OneOf("dockerfile", "pack", "nixpacks"),
+					stringvalidator.OneOf(
+						string(sevallaapi.BuildTypeDockerfile),
+						string(sevallaapi.BuildTypePack),
+						string(sevallaapi.BuildTypeNixpacks),
+					),
 				},
 			},
 			"node_version": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "The Node.js version to use (16.20.0, 18.16.0, 20.2.0).",
 				Validators: []validator.String{
-					stringvalidator.OneOf("16.20.0", "18.16.0", "20.2.0"),
+					stringvalidator.OneOf(
+						string(sevallaapi.NodeVersion16),
+						string(sevallaapi.NodeVersion18),
+						string(sevallaapi.NodeVersion20),
+					),
 				},
 			},
 			"dockerfile_path": schema.StringAttribute{
@@ -169,6 +253,20 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 				Optional:            true,
 				MarkdownDescription: "The path to the docker-compose file.",
 			},
+			"pack_config": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Settings for `pack` builds. Required when `build_type` is `pack` and not allowed otherwise. " +
+					"The API does not return these settings, so changes made outside Terraform are not detected.",
+				Attributes: map[string]schema.Attribute{
+					"builder": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "The buildpack builder image, such as `heroku/builder:24`.",
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+				},
+			},
 			"start_command": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "The start command for the application.",
@@ -178,10 +276,12 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "The install command for the application.",
 			},
 			"environment_variables": schema.ListNestedAttribute{
-				Optional:            true,
-				Computed:            true,
-				Default:             listdefault.StaticValue(types.ListValueMust(types.ObjectType{AttrTypes: map[string]attr.Type{"key": types.StringType, "value": types.StringType}}, []attr.Value{})),
-				MarkdownDescription: "Environment variables for the application.",
+				Optional: true,
+				Computed: true,
+				Default:  listdefault.StaticValue(types.ListValueMust(types.ObjectType{AttrTypes: environmentVariableAttrTypes}, []attr.Value{})),
+				MarkdownDescription: "Environment variables for the application. Each variable sets either `value` or `from_database`. " +
+					"Variables keep their configured order regardless of the order the API returns them in; " +
+					"variables added outside Terraform are listed last, sorted by key.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"key": schema.StringAttribute{
@@ -189,13 +289,53 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 							MarkdownDescription: "The environment variable key.",
 						},
 						"value": schema.StringAttribute{
-							Required:            true,
+							Optional:            true,
+							Computed:            true,
 							Sensitive:           true,
-							MarkdownDescription: "The environment variable value.",
+							MarkdownDescription: "The environment variable value. Computed when `from_database` is set.",
+						},
+						"from_database": schema.StringAttribute{
+							Optional: true,
+							MarkdownDescription: "The ID of a database to source the value from, for example `sevalla_database.db.id`. " +
+								"The value is resolved when the application is created or updated.",
+						},
+						"from_database_property": schema.StringAttribute{
+							Optional: true,
+							MarkdownDescription: "The database property to use with `from_database`: `internal_url` (default), " +
+								"`external_url`, `host`, `port`, `user`, `password` or `name`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(databaseEnvProperties...),
+							},
 						},
 					},
 				},
 			},
+			"environment_from": schema.ListAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.MapType{ElemType: types.StringType},
+				MarkdownDescription: "Sets of environment variables to merge into the application, such as a shared base set " +
+					"followed by per-environment overrides. Later sets override earlier ones, and `environment_variables` " +
+					"overrides them all. Variables set this way are not repeated in `environment_variables`.",
+				Validators: []validator.List{
+					listvalidator.NoNullValues(),
+					listvalidator.ValueMapsAre(mapvalidator.NoNullValues()),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The hostname the application is served on. Null until Sevalla assigns one.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The HTTPS URL of the application, built from `domain`. Null until Sevalla assigns a domain.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"created_at": schema.Int64Attribute{
 				Computed:            true,
 				MarkdownDescription: "The timestamp when the application was created.",
@@ -204,9 +344,56 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 				Computed:            true,
 				MarkdownDescription: "The timestamp when the application was last updated.",
 			},
+			"deployments_limit": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The maximum number of recent deployments to keep in `deployments`. Defaults to `0`, which omits the deployment history and only populates `latest_deployment`. Use the `sevalla_application` data source to read the full history.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"latest_deployment": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The most recent deployment of this application, or null if it has never been deployed. Its build logs are only kept in `deployments`.",
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The deployment ID.",
+					},
+					"status": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The deployment status.",
+					},
+					"branch": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The branch for this deployment.",
+					},
+					"repo_url": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The repository URL.",
+					},
+					"commit_hash": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The commit hash.",
+					},
+					"commit_message": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The commit message.",
+					},
+					"created_at": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "When the deployment was created.",
+					},
+					"updated_at": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "When the deployment was last updated.",
+					},
+				},
+			},
 			"deployments": schema.ListNestedAttribute{
 				Computed:            true,
-				MarkdownDescription: "List of deployments for this application.",
+				MarkdownDescription: "The most recent deployments for this application, newest first, capped at `deployments_limit`.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.StringAttribute{
@@ -280,6 +467,21 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 					},
 				},
 			},
+			"restart_trigger": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Any value; changing it restarts the application's processes without rebuilding it, " +
+					"for example after a configuration change, and waits for the restart deployment to finish. " +
+					"The application must be `deployed` to be restarted. Setting it when the application is created does not restart.",
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				MarkdownDescription: "Whether to wait after creation until the application is deployed or stopped. " +
+					"Set to false for applications that are not deployed on creation, such as ones without a `repo_url`. " +
+					"The wait counts against the create timeout. Defaults to true.",
+			},
+			"deletion_protection": deletionProtectionAttribute("application"),
 			"internal_connections": schema.ListNestedAttribute{
 				Computed:            true,
 				MarkdownDescription: "List of internal connections for this application.",
@@ -305,6 +507,52 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 				},
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *ApplicationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ApplicationResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateUniqueEnvVarKeys(ctx, data.EnvironmentVariables, path.Root("environment_variables"), &resp.Diagnostics)
+	validateEnvVarSources(ctx, data.EnvironmentVariables, path.Root("environment_variables"), &resp.Diagnostics)
+
+	if sevallaapi.BuildType(data.BuildType.ValueString()) == sevallaapi.BuildTypeDockerfile && data.DockerfilePath.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dockerfile_path"),
+			"Missing Dockerfile Path",
+			"Applications with build_type \"dockerfile\" must set dockerfile_path.",
+		)
+	}
+
+	if !data.BuildType.IsUnknown() && !data.PackConfig.IsUnknown() {
+		isPack := sevallaapi.BuildType(data.BuildType.ValueString()) == sevallaapi.BuildTypePack
+		switch {
+		case isPack && data.PackConfig.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root("pack_config"),
+				"Missing Pack Configuration",
+				"Applications with build_type \"pack\" must set pack_config.",
+			)
+		case !isPack && !data.PackConfig.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root("pack_config"),
+				"Unexpected Pack Configuration",
+				"pack_config can only be set when build_type is \"pack\".",
+			)
+		}
 	}
 }
 
@@ -323,6 +571,8 @@ func (r *ApplicationResource) Configure(ctx context.Context, req resource.Config
 	}
 
 	r.client = data.Client
+	r.companyID = data.CompanyID
+	r.conditionalUpdates = data.ConditionalUpdates
 }
 
 func (r *ApplicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -333,6 +583,12 @@ func (r *ApplicationResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	data.CompanyID = companyIDOrDefault(data.CompanyID, r.companyID)
+	if data.CompanyID.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("company_id"), "Missing Company ID", missingCompanyIDDetail)
+		return
+	}
+
 	createReq := sevallaapi.CreateApplicationRequest{
 		CompanyID:   data.CompanyID.ValueString(),
 		DisplayName: data.DisplayName.ValueString(),
@@ -352,18 +608,75 @@ func (r *ApplicationResource) Create(ctx context.Context, req resource.CreateReq
 		"repo_url":     createReq.RepoURL,
 	})
 
+	followUp := expandApplicationSettings(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	app, err := r.client.Applications.Create(ctx, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create application, got error: %s", err))
 		return
 	}
 
+	// Environment variables and most settings cannot be set on create, so they are applied with a follow-up update
+	followUp.EnvironmentVariables = r.expandEnvironmentVariables(ctx, data.EnvironmentVariables, path.Root("environment_variables"), &resp.Diagnostics)
+	followUp.EnvironmentVariables = withEnvironmentFrom(followUp.EnvironmentVariables, mergeEnvironmentFrom(ctx, data.EnvironmentFrom, &resp.Diagnostics))
+	if resp.Diagnostics.HasError() {
+		r.mapApplicationToModel(ctx, &data, &app.App)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	ctx = maskEnvVarValues(ctx, followUp.EnvironmentVariables)
+	tflog.Debug(ctx, "Applying application settings after create", map[string]interface{}{
+		"id":                    app.App.ID,
+		"environment_variables": envVarKeys(followUp.EnvironmentVariables),
+	})
+
+	updated, err := r.client.Applications.Update(ctx, app.App.ID, followUp)
+	if err != nil {
+		// Save the application that was created, so it is tainted and not created again on the next apply
+		r.mapApplicationToModel(ctx, &data, &app.App)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to apply application settings after create, got error: %s", err))
+		return
+	}
+	app = updated
+
 	// Map all fields from API response
 	r.mapApplicationToModel(ctx, &data, &app.App)
 
 	tflog.Trace(ctx, "Created application resource")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !data.WaitForReady.ValueBool() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultApplicationCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ready, err := r.waitForApplicationStatus(ctx, app.App.ID, createTimeout)
+	if ready != nil {
+		r.mapApplicationToModel(ctx, &data, &ready.App)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	}
+	if errors.Is(err, errApplicationFailed) {
+		resp.Diagnostics.AddError(
+			"Application Failed",
+			fmt.Sprintf("Application %s was created but reached the %q status. "+
+				"Check the latest deployment logs in Sevalla and re-apply to recreate it.", app.App.ID, ready.App.Status),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for application to become ready, got error: %s", err))
+		return
+	}
 }
 
 func (r *ApplicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -387,75 +700,122 @@ func (r *ApplicationResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *ApplicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ApplicationResourceModel
+	var data, state ApplicationResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	updateReq := sevallaapi.UpdateApplicationRequest{
-		DisplayName: stringPointer(data.DisplayName.ValueString()),
-	}
+	data.CompanyID = companyIDOrDefault(data.CompanyID, r.companyID)
 
-	// Add all updatable fields
-	if !data.BuildPath.IsNull() {
-		updateReq.BuildPath = stringPointer(data.BuildPath.ValueString())
-	}
-	if !data.BuildType.IsNull() {
-		buildType := sevallaapi.BuildType(data.BuildType.ValueString())
-		updateReq.BuildType = &buildType
+	updateReq := expandApplicationSettings(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if !data.DefaultBranch.IsNull() {
-		updateReq.DefaultBranch = stringPointer(data.DefaultBranch.ValueString())
+	updateReq.DisplayName = stringPointer(data.DisplayName.ValueString())
+
+	// Handle environment variables, resolving values sourced from databases
+	if !data.EnvironmentVariables.IsNull() {
+		updateReq.EnvironmentVariables = r.expandEnvironmentVariables(ctx, data.EnvironmentVariables, path.Root("environment_variables"), &resp.Diagnostics)
+		updateReq.EnvironmentVariables = withEnvironmentFrom(updateReq.EnvironmentVariables, mergeEnvironmentFrom(ctx, data.EnvironmentFrom, &resp.Diagnostics))
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
-	if !data.AutoDeploy.IsNull() {
-		autoDeploy := data.AutoDeploy.ValueBool()
-		updateReq.AutoDeploy = &autoDeploy
+
+	// Environment variables often hold secrets, so only their keys are logged
+	ctx = maskEnvVarValues(ctx, updateReq.EnvironmentVariables)
+	tflog.Debug(ctx, "Updating application", map[string]interface{}{
+		"id":                    data.ID.ValueString(),
+		"display_name":          data.DisplayName.ValueString(),
+		"environment_variables": envVarKeys(updateReq.EnvironmentVariables),
+	})
+
+	var app *sevallaapi.Application
+	var err error
+	if r.conditionalUpdates {
+		app, err = r.client.Applications.UpdateIfUnmodified(ctx, data.ID.ValueString(), state.UpdatedAt.ValueInt64(), updateReq)
+	} else {
+		app, err = r.client.Applications.Update(ctx, data.ID.ValueString(), updateReq)
 	}
-	if !data.NodeVersion.IsNull() {
-		nodeVersion := sevallaapi.NodeVersion(data.NodeVersion.ValueString())
-		updateReq.NodeVersion = &nodeVersion
+	if sevallaapi.IsConflict(err) {
+		resp.Diagnostics.AddError(
+			"Application Modified Concurrently",
+			fmt.Sprintf("The application was changed since it was last read, so the update was not applied. "+
+				"Run terraform refresh or plan again to review the remote changes. Details: %s", err),
+		)
+		return
 	}
-	if !data.DockerfilePath.IsNull() {
-		updateReq.DockerfilePath = stringPointer(data.DockerfilePath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update application, got error: %s", err))
+		return
 	}
-	if !data.DockerComposeFile.IsNull() {
-		updateReq.DockerComposeFile = stringPointer(data.DockerComposeFile.ValueString())
+
+	// Map all fields from API response
+	r.mapApplicationToModel(ctx, &data, &app.App)
+
+	if data.RestartTrigger.IsNull() || data.RestartTrigger.Equal(state.RestartTrigger) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
 	}
-	if !data.StartCommand.IsNull() {
-		updateReq.StartCommand = stringPointer(data.StartCommand.ValueString())
+
+	// Save the settings with the previous trigger, so a restart that does not happen is retried on the next apply
+	restartTrigger := data.RestartTrigger
+	data.RestartTrigger = state.RestartTrigger
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if !data.InstallCommand.IsNull() {
-		updateReq.InstallCommand = stringPointer(data.InstallCommand.ValueString())
+
+	if status := sevallaapi.ApplicationStatus(app.App.Status); status != sevallaapi.ApplicationStatusDeployed &&
+		status != sevallaapi.ApplicationStatusDeploymentSuccess {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("restart_trigger"),
+			"Application Not Restartable",
+			fmt.Sprintf("Application %s can only be restarted when it is %q, but it is %q. "+
+				"Wait for it to finish deploying, or redeploy it if it failed, then apply again.",
+				data.ID.ValueString(), sevallaapi.ApplicationStatusDeployed, app.App.Status),
+		)
+		return
 	}
 
-	// Handle environment variables
-	if !data.EnvironmentVariables.IsNull() {
-		var envVarModels []EnvironmentVariableModel
-		diags := data.EnvironmentVariables.ElementsAs(ctx, &envVarModels, false)
-		if !diags.HasError() {
-			envVars := make([]sevallaapi.EnvVar, len(envVarModels))
-			for i, envVar := range envVarModels {
-				envVars[i] = sevallaapi.EnvVar{
-					Key:   envVar.Key.ValueString(),
-					Value: envVar.Value.ValueString(),
-				}
-			}
-			updateReq.EnvironmentVariables = envVars
-		}
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultApplicationUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	app, err := r.client.Applications.Update(ctx, data.ID.ValueString(), updateReq)
+	tflog.Debug(ctx, "Restarting application", map[string]interface{}{"id": data.ID.ValueString()})
+
+	restart, err := r.client.Applications.Restart(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update application, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to restart application, got error: %s", err))
 		return
 	}
+	data.RestartTrigger = restartTrigger
 
-	// Map all fields from API response
-	r.mapApplicationToModel(ctx, &data, &app.App)
-
+	// The application status does not change until the restart deployment starts, so wait on the deployment itself
+	deployment, err := r.waitForRestartDeployment(ctx, restart.Deployment.ID, updateTimeout)
+	if err == nil || errors.Is(err, errDeploymentFailed) {
+		if app, getErr := r.client.Applications.Get(ctx, data.ID.ValueString()); getErr == nil {
+			r.mapApplicationToModel(ctx, &data, &app.App)
+		}
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if errors.Is(err, errDeploymentFailed) {
+		resp.Diagnostics.AddError(
+			"Application Failed",
+			fmt.Sprintf("Application %s was restarted but restart deployment %s finished with status %q. "+
+				"Check the deployment logs in Sevalla.", data.ID.ValueString(), restart.Deployment.ID, deployment.Status),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for application to restart, got error: %s", err))
+		return
+	}
 }
 
 func (r *ApplicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -466,15 +826,160 @@ func (r *ApplicationResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	err := r.client.Applications.Delete(ctx, data.ID.ValueString())
+	if !checkDeletionProtection(data.DeletionProtection, "application", data.ID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultApplicationDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := deleteRetryingConflicts(ctx, deleteConflictRetryInterval, deleteTimeout, func(ctx context.Context) error {
+		return r.client.Applications.Delete(ctx, data.ID.ValueString())
+	})
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete application, got error: %s", err))
 		return
 	}
+
+	if err := r.waitForApplicationDeleted(ctx, data.ID.ValueString(), deleteTimeout); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for application deletion, got error: %s", err))
+		return
+	}
 }
 
 func (r *ApplicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	companyID, applicationID, ok := parseCompanyScopedImportID(req.ID, "application")
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: company_id/application_id, application_id or "+
+				"company/company_id/application/application_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	if companyID == "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), applicationID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_ready"), true)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+		return
+	}
+
+	// Read fills in the rest of the state, so the application is only fetched to check its company
+	app, err := r.client.Applications.Get(ctx, applicationID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read application %s for import, got error: %s", applicationID, err))
+		return
+	}
+	if app.App.CompanyID != companyID {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Application %s belongs to company %s, not %s.", applicationID, app.App.CompanyID, companyID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), applicationID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("company_id"), companyID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_ready"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+}
+
+// waitForApplicationStatus polls the application until it settles and returns
+// the last application read. It returns errApplicationFailed if the application fails.
+func (r *ApplicationResource) waitForApplicationStatus(
+	ctx context.Context,
+	id string,
+	timeout time.Duration,
+) (*sevallaapi.Application, error) {
+	ctx, cancel := r.client.WithDeadline(ctx)
+	defer cancel()
+
+	ticker := time.NewTicker(applicationPollInterval)
+	defer ticker.Stop()
+	deadline := time.After(timeout)
+
+	for {
+		select {
+		case <-ticker.C:
+			app, err := r.client.Applications.Get(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get application status: %w", err)
+			}
+
+			tflog.Debug(ctx, "Polled application status", map[string]interface{}{
+				"id":     id,
+				"status": app.App.Status,
+			})
+
+			switch sevallaapi.ApplicationStatus(app.App.Status) {
+			case sevallaapi.ApplicationStatusDeployed, sevallaapi.ApplicationStatusStopped,
+				sevallaapi.ApplicationStatusDeploymentSuccess, sevallaapi.ApplicationStatusDeploymentCancelled:
+				return app, nil
+			case sevallaapi.ApplicationStatusFailed, sevallaapi.ApplicationStatusDeploymentFailed:
+				return app, errApplicationFailed
+			}
+		case <-deadline:
+			return nil, fmt.Errorf("application did not become ready after %s", timeout)
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+	}
+}
+
+// waitForRestartDeployment polls the restart deployment until it reaches one of the default terminal statuses.
+// It returns errDeploymentFailed together with the deployment if it reached a failure status.
+func (r *ApplicationResource) waitForRestartDeployment(
+	ctx context.Context,
+	deploymentID string,
+	timeout time.Duration,
+) (*sevallaapi.ApplicationDeployment, error) {
+	ctx, cancel := r.client.WithDeadline(ctx)
+	defer cancel()
+
+	ticker := time.NewTicker(applicationPollInterval)
+	defer ticker.Stop()
+	deadline := time.After(timeout)
+
+	for {
+		select {
+		case <-ticker.C:
+			resp, err := r.client.Deployments.GetByID(ctx, deploymentID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get restart deployment status: %w", err)
+			}
+
+			tflog.Debug(ctx, "Polled restart deployment status", map[string]interface{}{
+				"deployment_id": deploymentID,
+				"status":        resp.Deployment.Status,
+			})
+
+			switch {
+			case slices.Contains(defaultDeploymentSuccessStatuses, resp.Deployment.Status):
+				return &resp.Deployment, nil
+			case slices.Contains(defaultDeploymentFailureStatuses, resp.Deployment.Status):
+				return &resp.Deployment, errDeploymentFailed
+			}
+		case <-deadline:
+			return nil, fmt.Errorf("restart deployment did not finish after %s", timeout)
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+	}
+}
+
+// waitForApplicationDeleted polls the application until the API no longer returns it.
+func (r *ApplicationResource) waitForApplicationDeleted(ctx context.Context, id string, timeout time.Duration) error {
+	ctx, cancel := r.client.WithDeadline(ctx)
+	defer cancel()
+
+	return waitForDeletion(ctx, applicationPollInterval, timeout, func(ctx context.Context) error {
+		_, err := r.client.Applications.Get(ctx, id)
+		return err
+	})
 }
 
 // mapApplicationToModel maps API response to Terraform model
@@ -493,77 +998,77 @@ func (r *ApplicationResource) mapApplicationToModel(ctx context.Context, data *A
 	data.AutoDeploy = types.BoolValue(app.AutoDeploy)
 
 	// Build configuration
-	data.BuildPath = types.StringValue(app.BuildPath)
 	data.BuildType = types.StringValue(app.BuildType)
 	data.NodeVersion = types.StringValue(app.NodeVersion)
-	data.DockerfilePath = types.StringValue(app.DockerfilePath)
-	data.DockerComposeFile = types.StringValue(app.DockerComposeFile)
-	data.StartCommand = types.StringValue(app.StartCommand)
-	data.InstallCommand = types.StringValue(app.InstallCommand)
-
-	// Convert environment variables
-	envVars := make([]attr.Value, len(app.EnvironmentVariables))
-	for i, envVar := range app.EnvironmentVariables {
+	// Unset paths and commands come back empty; keep them null so an omitted argument does not show a diff
+	data.BuildPath = stringValueOrNull(app.BuildPath)
+	data.DockerfilePath = stringValueOrNull(app.DockerfilePath)
+	data.DockerComposeFile = stringValueOrNull(app.DockerComposeFile)
+	data.StartCommand = stringValueOrNull(app.StartCommand)
+	data.InstallCommand = stringValueOrNull(app.InstallCommand)
+
+	// Applications only get a hostname once Sevalla assigns one
+	data.Domain = stringValueOrNull(app.Hostname)
+	data.URL = types.StringNull()
+	if app.Hostname != "" {
+		data.URL = types.StringValue("https://" + app.Hostname)
+	}
+
+	// Variables merged from environment_from are tracked there rather than in environment_variables
+	explicit := envVarKeySet(ctx, data.EnvironmentVariables)
+	actual := make(map[string]string, len(app.EnvironmentVariables))
+	for _, envVar := range app.EnvironmentVariables {
+		actual[envVar.Key] = envVar.Value
+	}
+	var fromKeys map[string]bool
+	data.EnvironmentFrom, fromKeys = refreshEnvironmentFrom(ctx, data.EnvironmentFrom, explicit, actual)
+	appEnvVars := make([]sevallaapi.EnvVar, 0, len(app.EnvironmentVariables))
+	for _, envVar := range app.EnvironmentVariables {
+		if !fromKeys[envVar.Key] {
+			appEnvVars = append(appEnvVars, envVar)
+		}
+	}
+
+	// Convert environment variables, keeping the database references the values were resolved from
+	sources := envVarSources(ctx, data.EnvironmentVariables)
+	envVars := make([]attr.Value, len(appEnvVars))
+	for i, envVar := range orderEnvVars(ctx, data.EnvironmentVariables, appEnvVars) {
+		source, ok := sources[envVar.Key]
+		if !ok {
+			source.FromDatabase = types.StringNull()
+			source.FromDatabaseProperty = types.StringNull()
+		}
 		envVarObj, _ := types.ObjectValue(
-			map[string]attr.Type{
-				"key":   types.StringType,
-				"value": types.StringType,
-			},
+			environmentVariableAttrTypes,
 			map[string]attr.Value{
-				"key":   types.StringValue(envVar.Key),
-				"value": types.StringValue(envVar.Value),
+				"key":                    types.StringValue(envVar.Key),
+				"value":                  types.StringValue(envVar.Value),
+				"from_database":          source.FromDatabase,
+				"from_database_property": source.FromDatabaseProperty,
 			},
 		)
 		envVars[i] = envVarObj
 	}
-	data.EnvironmentVariables, _ = types.ListValue(
-		types.ObjectType{AttrTypes: map[string]attr.Type{"key": types.StringType, "value": types.StringType}},
-		envVars,
-	)
+	data.EnvironmentVariables, _ = types.ListValue(types.ObjectType{AttrTypes: environmentVariableAttrTypes}, envVars)
 
-	// Convert deployments
-	deployments := make([]attr.Value, len(app.Deployments))
-	for i, deployment := range app.Deployments {
-		commitMsg := ""
-		if deployment.CommitMessage != nil {
-			commitMsg = *deployment.CommitMessage
-		}
-		deploymentObj, _ := types.ObjectValue(
-			map[string]attr.Type{
-				"id":             types.StringType,
-				"status":         types.StringType,
-				"branch":         types.StringType,
-				"repo_url":       types.StringType,
-				"commit_hash":    types.StringType,
-				"commit_message": types.StringType,
-				"created_at":     types.Int64Type,
-				"updated_at":     types.Int64Type,
-				"build_logs":     types.StringType,
-			},
-			map[string]attr.Value{
-				"id":             types.StringValue(deployment.ID),
-				"status":         types.StringValue(deployment.Status),
-				"branch":         types.StringValue(deployment.Branch),
-				"repo_url":       types.StringValue(deployment.RepoURL),
-				"commit_hash":    types.StringValue(deployment.CommitHash),
-				"commit_message": types.StringValue(commitMsg),
-				"created_at":     types.Int64Value(deployment.CreatedAt),
-				"updated_at":     types.Int64Value(deployment.UpdatedAt),
-				"build_logs":     types.StringValue(deployment.BuildLogs),
-			},
-		)
-		deployments[i] = deploymentObj
-	}
-	deploymentAttrTypes := map[string]attr.Type{
-		"id":             types.StringType,
-		"status":         types.StringType,
-		"branch":         types.StringType,
-		"repo_url":       types.StringType,
-		"commit_hash":    types.StringType,
-		"commit_message": types.StringType,
-		"created_at":     types.Int64Type,
-		"updated_at":     types.Int64Type,
-		"build_logs":     types.StringType,
+	// Convert deployments, keeping only the most recent ones in state
+	limit := int(data.DeploymentsLimit.ValueInt64())
+	if data.DeploymentsLimit.IsNull() || data.DeploymentsLimit.IsUnknown() {
+		data.DeploymentsLimit = types.Int64Value(0)
+		limit = 0
+	}
+	recent := recentDeployments(app.Deployments)
+	if len(recent) > 0 {
+		data.LatestDeployment = latestDeploymentObjectValue(recent[0])
+	} else {
+		data.LatestDeployment = types.ObjectNull(latestDeploymentAttrTypes)
+	}
+	if len(recent) > limit {
+		recent = recent[:limit]
+	}
+	deployments := make([]attr.Value, len(recent))
+	for i, deployment := range recent {
+		deployments[i] = deploymentObjectValue(deployment)
 	}
 	data.Deployments, _ = types.ListValue(types.ObjectType{AttrTypes: deploymentAttrTypes}, deployments)
 
@@ -628,7 +1133,140 @@ func (r *ApplicationResource) mapApplicationToModel(ctx context.Context, data *A
 	data.InternalConnections, _ = types.ListValue(types.ObjectType{AttrTypes: connAttrTypes}, connections)
 }
 
+// expandApplicationSettings returns an update request carrying the configured git, build and
+// command settings. Apart from the default branch, the create endpoint does not accept them.
+func expandApplicationSettings(ctx context.Context, data ApplicationResourceModel, diags *diag.Diagnostics) sevallaapi.UpdateApplicationRequest {
+	var updateReq sevallaapi.UpdateApplicationRequest
+	if !data.DefaultBranch.IsNull() {
+		updateReq.DefaultBranch = stringPointer(data.DefaultBranch.ValueString())
+	}
+	if !data.AutoDeploy.IsNull() {
+		autoDeploy := data.AutoDeploy.ValueBool()
+		updateReq.AutoDeploy = &autoDeploy
+	}
+	if !data.BuildPath.IsNull() {
+		updateReq.BuildPath = stringPointer(data.BuildPath.ValueString())
+	}
+	if !data.NodeVersion.IsNull() {
+		nodeVersion := sevallaapi.NodeVersion(data.NodeVersion.ValueString())
+		updateReq.NodeVersion = &nodeVersion
+	}
+	if !data.BuildType.IsNull() {
+		buildType := sevallaapi.BuildType(data.BuildType.ValueString())
+		updateReq.BuildType = &buildType
+	}
+	if !data.DockerfilePath.IsNull() {
+		updateReq.DockerfilePath = stringPointer(data.DockerfilePath.ValueString())
+	}
+	if !data.DockerComposeFile.IsNull() {
+		updateReq.DockerComposeFile = stringPointer(data.DockerComposeFile.ValueString())
+	}
+	if !data.InstallCommand.IsNull() {
+		updateReq.InstallCommand = stringPointer(data.InstallCommand.ValueString())
+	}
+	if !data.StartCommand.IsNull() {
+		updateReq.StartCommand = stringPointer(data.StartCommand.ValueString())
+	}
+	updateReq.PackConfig = expandPackConfig(ctx, data, diags)
+	return updateReq
+}
+
+// expandPackConfig returns the planned pack settings, or nil unless the application is built with pack.
+func expandPackConfig(ctx context.Context, data ApplicationResourceModel, diags *diag.Diagnostics) *sevallaapi.PackConfig {
+	if sevallaapi.BuildType(data.BuildType.ValueString()) != sevallaapi.BuildTypePack || data.PackConfig.IsNull() || data.PackConfig.IsUnknown() {
+		return nil
+	}
+
+	var model PackConfigModel
+	diags.Append(data.PackConfig.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+	return &sevallaapi.PackConfig{Builder: model.Builder.ValueString()}
+}
+
+// validateUniqueEnvVarKeys reports an error for every environment variable key
+// that appears more than once, since the API behavior for duplicates is undefined.
+func validateUniqueEnvVarKeys(ctx context.Context, envVars types.List, attrPath path.Path, diags *diag.Diagnostics) {
+	if envVars.IsNull() || envVars.IsUnknown() {
+		return
+	}
+
+	var envVarModels []EnvironmentVariableModel
+	diags.Append(envVars.ElementsAs(ctx, &envVarModels, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	seen := make(map[string]int, len(envVarModels))
+	for i, envVar := range envVarModels {
+		if envVar.Key.IsNull() || envVar.Key.IsUnknown() {
+			continue
+		}
+		key := envVar.Key.ValueString()
+		if first, ok := seen[key]; ok {
+			diags.AddAttributeError(
+				attrPath.AtListIndex(i).AtName("key"),
+				"Duplicate Environment Variable Key",
+				fmt.Sprintf("The environment variable key %q is defined more than once (entries %d and %d). "+
+					"Each key must be unique.", key, first, i),
+			)
+			continue
+		}
+		seen[key] = i
+	}
+}
+
+// recentDeployments returns a copy of the deployments ordered newest first.
+func recentDeployments(deployments []sevallaapi.AppDeployment) []sevallaapi.AppDeployment {
+	sorted := make([]sevallaapi.AppDeployment, len(deployments))
+	copy(sorted, deployments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt > sorted[j].CreatedAt
+	})
+	return sorted
+}
+
+// deploymentObjectValue converts an API deployment into its state object.
+func deploymentObjectValue(deployment sevallaapi.AppDeployment) types.Object {
+	commitMsg := ""
+	if deployment.CommitMessage != nil {
+		commitMsg = *deployment.CommitMessage
+	}
+	obj, _ := types.ObjectValue(
+		deploymentAttrTypes,
+		map[string]attr.Value{
+			"id":             types.StringValue(deployment.ID),
+			"status":         types.StringValue(deployment.Status),
+			"branch":         types.StringValue(deployment.Branch),
+			"repo_url":       types.StringValue(deployment.RepoURL),
+			"commit_hash":    types.StringValue(deployment.CommitHash),
+			"commit_message": types.StringValue(commitMsg),
+			"created_at":     types.Int64Value(deployment.CreatedAt),
+			"updated_at":     types.Int64Value(deployment.UpdatedAt),
+			"build_logs":     types.StringValue(deployment.BuildLogs),
+		},
+	)
+	return obj
+}
+
+// latestDeploymentObjectValue converts an API deployment into the latest_deployment state object.
+func latestDeploymentObjectValue(deployment sevallaapi.AppDeployment) types.Object {
+	attributes := deploymentObjectValue(deployment).Attributes()
+	delete(attributes, "build_logs")
+	obj, _ := types.ObjectValue(latestDeploymentAttrTypes, attributes)
+	return obj
+}
+
 // Helper function to convert string to pointer.
 func stringPointer(s string) *string {
 	return &s
 }
+
+// stringValueOrNull returns a null string for the empty string.
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -1,10 +1,22 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
 )
 
 func TestAccApplicationResource(t *testing.T) {
@@ -66,3 +78,903 @@ resource "sevalla_application" "test" {
 }
 `, name, testAccCompanyID())
 }
+
+func testApplicationStatusServer(t *testing.T, statuses ...string) (*ApplicationResource, *sevallaapitest.Server) {
+	t.Helper()
+
+	applicationPollInterval = time.Millisecond
+	t.Cleanup(func() { applicationPollInterval = 5 * time.Second })
+
+	server := sevallaapitest.NewServer(t)
+	var calls int
+	server.Handle(http.MethodGet, "/applications/{id}", func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		body := strings.Replace(sevallaapitest.ApplicationFixture, `"status": "deployed"`, fmt.Sprintf(`"status": %q`, status), 1)
+		sevallaapitest.JSONResponse(http.StatusOK, body)(w, r)
+	})
+
+	r := &ApplicationResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+	return r, server
+}
+
+func TestWaitForApplicationStatus(t *testing.T) {
+	t.Run("deployed", func(t *testing.T) {
+		r, server := testApplicationStatusServer(t, "deploying", "deploying", "deployed")
+
+		app, err := r.waitForApplicationStatus(context.Background(), sevallaapitest.ApplicationID, time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if app.App.Status != "deployed" {
+			t.Errorf("expected deployed status, got %q", app.App.Status)
+		}
+		if got := len(server.Requests()); got != 3 {
+			t.Errorf("expected 3 polls, got %d", got)
+		}
+	})
+
+	t.Run("deployment success", func(t *testing.T) {
+		r, _ := testApplicationStatusServer(t, "deploymentInProgress", "deploymentSuccess")
+
+		if _, err := r.waitForApplicationStatus(context.Background(), sevallaapitest.ApplicationID, time.Second); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("deployment failed", func(t *testing.T) {
+		r, _ := testApplicationStatusServer(t, "deploymentInProgress", "deploymentFailed")
+
+		_, err := r.waitForApplicationStatus(context.Background(), sevallaapitest.ApplicationID, time.Second)
+		if !errors.Is(err, errApplicationFailed) {
+			t.Fatalf("expected errApplicationFailed, got %v", err)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		r, _ := testApplicationStatusServer(t, "deploying", "failed")
+
+		_, err := r.waitForApplicationStatus(context.Background(), sevallaapitest.ApplicationID, time.Second)
+		if !errors.Is(err, errApplicationFailed) {
+			t.Fatalf("expected errApplicationFailed, got %v", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		r, _ := testApplicationStatusServer(t, "deploying")
+
+		_, err := r.waitForApplicationStatus(context.Background(), sevallaapitest.ApplicationID, 20*time.Millisecond)
+		if err == nil || errors.Is(err, errApplicationFailed) {
+			t.Fatalf("expected a timeout error, got %v", err)
+		}
+	})
+}
+
+func TestWaitForApplicationDeleted(t *testing.T) {
+	r, server := testApplicationStatusServer(t, "stopped")
+	server.HandleJSON(http.MethodGet, "/applications/{id}", http.StatusNotFound, `{"message":"App not found","status":404}`)
+
+	if err := r.waitForApplicationDeleted(context.Background(), sevallaapitest.ApplicationID, time.Second); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestApplicationResourceValidateConfig_BuildSettings(t *testing.T) {
+	ctx := context.Background()
+	r := &ApplicationResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	packConfigType := objectType.AttributeTypes["pack_config"]
+	noPackConfig := tftypes.NewValue(packConfigType, nil)
+	packConfig := tftypes.NewValue(packConfigType, map[string]tftypes.Value{
+		"builder": tftypes.NewValue(tftypes.String, "heroku/builder:24"),
+	})
+	noPath := tftypes.NewValue(tftypes.String, nil)
+
+	tests := map[string]struct {
+		buildType, dockerfilePath, packConfig tftypes.Value
+		wantErrors                            int
+	}{
+		"dockerfile":         {tftypes.NewValue(tftypes.String, "dockerfile"), tftypes.NewValue(tftypes.String, "Dockerfile"), noPackConfig, 0},
+		"dockerfile no path": {tftypes.NewValue(tftypes.String, "dockerfile"), noPath, noPackConfig, 1},
+		"unknown path":       {tftypes.NewValue(tftypes.String, "dockerfile"), tftypes.NewValue(tftypes.String, tftypes.UnknownValue), noPackConfig, 0},
+		"nixpacks":           {tftypes.NewValue(tftypes.String, "nixpacks"), noPath, noPackConfig, 0},
+		"unset":              {tftypes.NewValue(tftypes.String, nil), noPath, noPackConfig, 0},
+		"pack":               {tftypes.NewValue(tftypes.String, "pack"), noPath, packConfig, 0},
+		"pack no config":     {tftypes.NewValue(tftypes.String, "pack"), noPath, noPackConfig, 1},
+		"config not pack":    {tftypes.NewValue(tftypes.String, "nixpacks"), noPath, packConfig, 1},
+		"unknown build type": {tftypes.NewValue(tftypes.String, tftypes.UnknownValue), noPath, packConfig, 0},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := testNullValues(objectType)
+			values["build_type"] = tt.buildType
+			values["dockerfile_path"] = tt.dockerfilePath
+			values["pack_config"] = tt.packConfig
+
+			req := fwresource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+			}
+			resp := &fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, req, resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != tt.wantErrors {
+				t.Errorf("expected %d errors, got %d: %v", tt.wantErrors, got, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestApplicationResourceValidateConfig_EnvironmentVariables(t *testing.T) {
+	ctx := context.Background()
+	r := &ApplicationResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	envVarsType, ok := objectType.AttributeTypes["environment_variables"].(tftypes.List)
+	if !ok {
+		t.Fatalf("expected environment_variables to be a list, got %T", objectType.AttributeTypes["environment_variables"])
+	}
+	envVarType, ok := envVarsType.ElementType.(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected environment variables to be objects, got %T", envVarsType.ElementType)
+	}
+	envVar := func(key tftypes.Value) tftypes.Value {
+		values := testNullValues(envVarType)
+		values["key"] = key
+		values["value"] = tftypes.NewValue(tftypes.String, "value")
+		return tftypes.NewValue(envVarType, values)
+	}
+	key := func(key string) tftypes.Value { return tftypes.NewValue(tftypes.String, key) }
+	unknownKey := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	tests := map[string]struct {
+		envVars    []tftypes.Value
+		wantErrors int
+	}{
+		"distinct":        {[]tftypes.Value{envVar(key("NODE_ENV")), envVar(key("PORT"))}, 0},
+		"duplicate":       {[]tftypes.Value{envVar(key("NODE_ENV")), envVar(key("PORT")), envVar(key("NODE_ENV"))}, 1},
+		"each duplicate":  {[]tftypes.Value{envVar(key("PORT")), envVar(key("PORT")), envVar(key("PORT"))}, 2},
+		"case sensitive":  {[]tftypes.Value{envVar(key("port")), envVar(key("PORT"))}, 0},
+		"unknown keys":    {[]tftypes.Value{envVar(unknownKey), envVar(unknownKey), envVar(key("PORT"))}, 0},
+		"unknown and key": {[]tftypes.Value{envVar(unknownKey), envVar(key("PORT")), envVar(key("PORT"))}, 1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := testNullValues(objectType)
+			values["environment_variables"] = tftypes.NewValue(envVarsType, tt.envVars)
+
+			req := fwresource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+			}
+			resp := &fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, req, resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != tt.wantErrors {
+				t.Fatalf("expected %d errors, got %d: %v", tt.wantErrors, got, resp.Diagnostics)
+			}
+			for _, d := range resp.Diagnostics.Errors() {
+				if d.Summary() != "Duplicate Environment Variable Key" {
+					t.Errorf("expected a duplicate key error, got %q", d.Summary())
+				}
+			}
+		})
+	}
+
+	t.Run("unknown list", func(t *testing.T) {
+		values := testNullValues(objectType)
+		values["environment_variables"] = tftypes.NewValue(envVarsType, tftypes.UnknownValue)
+
+		resp := &fwresource.ValidateConfigResponse{}
+		r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+	})
+}
+
+// testApplicationUpdate runs Update with a plan that sets the given attributes on top of the required ones.
+func testApplicationUpdate(t *testing.T, r *ApplicationResource, attributes map[string]tftypes.Value) ApplicationResourceModel {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := testNullValues(objectType)
+	values["id"] = tftypes.NewValue(tftypes.String, sevallaapitest.ApplicationID)
+	values["display_name"] = tftypes.NewValue(tftypes.String, "my-app")
+	values["deployments_limit"] = tftypes.NewValue(tftypes.Number, 0)
+	for name, value := range attributes {
+		values[name] = value
+	}
+	plan := tftypes.NewValue(objectType, values)
+
+	resp := &fwresource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Update(ctx, fwresource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: plan},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data ApplicationResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	return data
+}
+
+func TestApplicationResourceUpdate_PackConfig(t *testing.T) {
+	server := sevallaapitest.NewServer(t)
+	r := &ApplicationResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	packConfig := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"builder": tftypes.String}}, map[string]tftypes.Value{
+		"builder": tftypes.NewValue(tftypes.String, "heroku/builder:24"),
+	})
+	update := func(t *testing.T, buildType string) string {
+		t.Helper()
+
+		testApplicationUpdate(t, r, map[string]tftypes.Value{
+			"build_type":  tftypes.NewValue(tftypes.String, buildType),
+			"pack_config": packConfig,
+		})
+		requests := server.Requests()
+		return string(requests[len(requests)-1].Body)
+	}
+
+	if body := update(t, "pack"); !strings.Contains(body, `"pack_config":{"builder":"heroku/builder:24"}`) {
+		t.Errorf("expected pack_config to be sent for pack builds, got %s", body)
+	}
+	if body := update(t, "nixpacks"); strings.Contains(body, "pack_config") {
+		t.Errorf("expected pack_config not to be sent for other build types, got %s", body)
+	}
+}
+
+func TestApplicationResourceUpdate_InstallCommand(t *testing.T) {
+	t.Run("set", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		r := &ApplicationResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+		data := testApplicationUpdate(t, r, map[string]tftypes.Value{
+			"install_command": tftypes.NewValue(tftypes.String, "npm ci"),
+		})
+
+		requests := server.Requests()
+		if len(requests) != 1 || requests[0].Method != http.MethodPut {
+			t.Fatalf("expected a single update call, got %+v", requests)
+		}
+		if !strings.Contains(string(requests[0].Body), `"install_command":"npm ci"`) {
+			t.Errorf("expected install_command to be sent, got %s", requests[0].Body)
+		}
+		if data.InstallCommand.ValueString() != "npm ci" {
+			t.Errorf("expected install_command to be read back, got %s", data.InstallCommand)
+		}
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		body := strings.Replace(sevallaapitest.ApplicationFixture, `"install_command": "npm ci"`, `"install_command": ""`, 1)
+		server.HandleJSON(http.MethodPut, "/applications/{id}", http.StatusOK, body)
+		r := &ApplicationResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+		data := testApplicationUpdate(t, r, nil)
+
+		if got := len(server.Requests()); got != 1 {
+			t.Fatalf("expected a single update call, got %d", got)
+		}
+		if !data.InstallCommand.IsNull() {
+			t.Errorf("expected an empty install_command to be read back as null, got %s", data.InstallCommand)
+		}
+	})
+}
+
+func TestApplicationResourceUpdate_BuildPath(t *testing.T) {
+	t.Run("set", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		body := strings.Replace(sevallaapitest.ApplicationFixture, `"build_path": ""`, `"build_path": "apps/web"`, 1)
+		server.HandleJSON(http.MethodPut, "/applications/{id}", http.StatusOK, body)
+		r := &ApplicationResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+		data := testApplicationUpdate(t, r, map[string]tftypes.Value{
+			"build_path": tftypes.NewValue(tftypes.String, "apps/web"),
+		})
+
+		req, _ := server.LastRequest()
+		if !strings.Contains(string(req.Body), `"build_path":"apps/web"`) {
+			t.Errorf("expected build_path to be sent, got %s", req.Body)
+		}
+		if data.BuildPath.ValueString() != "apps/web" {
+			t.Errorf("expected build_path to be read back, got %s", data.BuildPath)
+		}
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		r := &ApplicationResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+		data := testApplicationUpdate(t, r, nil)
+
+		req, _ := server.LastRequest()
+		if strings.Contains(string(req.Body), "build_path") {
+			t.Errorf("expected an unset build_path not to be sent, got %s", req.Body)
+		}
+		if !data.BuildPath.IsNull() {
+			t.Errorf("expected an empty build_path to be read back as null, got %s", data.BuildPath)
+		}
+	})
+}
+
+func TestApplicationResourceUpdate_Domain(t *testing.T) {
+	t.Run("assigned", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		r := &ApplicationResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+		data := testApplicationUpdate(t, r, nil)
+
+		if data.Domain.ValueString() != "my-app-abc12.sevalla.app" {
+			t.Errorf("expected the hostname as domain, got %s", data.Domain)
+		}
+		if data.URL.ValueString() != "https://my-app-abc12.sevalla.app" {
+			t.Errorf("expected an https URL, got %s", data.URL)
+		}
+	})
+
+	t.Run("not assigned", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		body := strings.Replace(sevallaapitest.ApplicationFixture, `"hostname": "my-app-abc12.sevalla.app",`, "", 1)
+		server.HandleJSON(http.MethodPut, "/applications/{id}", http.StatusOK, body)
+		r := &ApplicationResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+		data := testApplicationUpdate(t, r, nil)
+
+		if !data.Domain.IsNull() || !data.URL.IsNull() {
+			t.Errorf("expected a null domain and url without a hostname, got %s and %s", data.Domain, data.URL)
+		}
+	})
+}
+
+func TestApplicationResourceUpdate_DeploymentsLimit(t *testing.T) {
+	deployment := func(id string, createdAt int) string {
+		return fmt.Sprintf(`{"id": %q, "status": "successful", "branch": "main", "repo_url": "https://github.com/example/my-app", `+
+			`"commit_hash": "a1b2c3d", "created_at": %d, "build_logs": "Build succeeded"}`, id, createdAt)
+	}
+	// The API does not order deployments, so the newest one is in the middle
+	deployments := "[" + deployment("dep-old", 1) + ", " + deployment("dep-new", 3) + ", " + deployment("dep-mid", 2) + "]"
+	i := strings.Index(sevallaapitest.ApplicationFixture, `"deployments": [`)
+	j := i + strings.Index(sevallaapitest.ApplicationFixture[i:], "],") + 1
+	body := sevallaapitest.ApplicationFixture[:i] + `"deployments": ` + deployments + sevallaapitest.ApplicationFixture[j:]
+
+	server := sevallaapitest.NewServer(t)
+	server.HandleJSON(http.MethodGet, "/applications/{id}", http.StatusOK, body)
+	server.HandleJSON(http.MethodPut, "/applications/{id}", http.StatusOK, body)
+	r := &ApplicationResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	deploymentIDs := func(t *testing.T, deployments types.List) []string {
+		t.Helper()
+
+		var ids []string
+		for _, element := range deployments.Elements() {
+			object, ok := element.(types.Object)
+			if !ok {
+				t.Fatalf("expected a deployment object, got %T", element)
+			}
+			id, ok := object.Attributes()["id"].(types.String)
+			if !ok {
+				t.Fatalf("expected a string deployment ID, got %T", object.Attributes()["id"])
+			}
+			ids = append(ids, id.ValueString())
+		}
+		return ids
+	}
+
+	for name, tt := range map[string]struct {
+		limit int
+		want  []string
+	}{
+		"omitted":       {limit: 0, want: nil},
+		"capped":        {limit: 2, want: []string{"dep-new", "dep-mid"}},
+		"above history": {limit: 10, want: []string{"dep-new", "dep-mid", "dep-old"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data := testApplicationUpdate(t, r, map[string]tftypes.Value{
+				"deployments_limit": tftypes.NewValue(tftypes.Number, tt.limit),
+			})
+
+			if got := deploymentIDs(t, data.Deployments); !slices.Equal(got, tt.want) {
+				t.Errorf("expected deployments %v, got %v", tt.want, got)
+			}
+			if data.DeploymentsLimit.ValueInt64() != int64(tt.limit) {
+				t.Errorf("expected deployments_limit %d, got %s", tt.limit, data.DeploymentsLimit)
+			}
+			if id := data.LatestDeployment.Attributes()["id"]; !id.Equal(types.StringValue("dep-new")) {
+				t.Errorf("expected the newest deployment as latest_deployment, got %s", id)
+			}
+			if _, ok := data.LatestDeployment.Attributes()["build_logs"]; ok {
+				t.Error("expected latest_deployment to leave out the build logs")
+			}
+			for _, element := range data.Deployments.Elements() {
+				if object, ok := element.(types.Object); !ok || !object.Attributes()["build_logs"].Equal(types.StringValue("Build succeeded")) {
+					t.Errorf("expected deployments to keep the build logs, got %s", element)
+				}
+			}
+		})
+	}
+
+	t.Run("no deployments", func(t *testing.T) {
+		empty := sevallaapitest.ApplicationFixture[:i] + `"deployments": []` + sevallaapitest.ApplicationFixture[j:]
+		server.HandleJSON(http.MethodGet, "/applications/{id}", http.StatusOK, empty)
+		server.HandleJSON(http.MethodPut, "/applications/{id}", http.StatusOK, empty)
+
+		data := testApplicationUpdate(t, r, map[string]tftypes.Value{
+			"deployments_limit": tftypes.NewValue(tftypes.Number, 2),
+		})
+		if !data.LatestDeployment.IsNull() || len(data.Deployments.Elements()) != 0 {
+			t.Errorf("expected no latest_deployment and no deployments, got %s and %s", data.LatestDeployment, data.Deployments)
+		}
+	})
+}
+
+func TestApplicationResourceCreate_Settings(t *testing.T) {
+	ctx := context.Background()
+	r, server := testApplicationStatusServer(t, "deployed")
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := testNullValues(objectType)
+	values["company_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.CompanyID)
+	values["display_name"] = tftypes.NewValue(tftypes.String, "my-app")
+	values["deployments_limit"] = tftypes.NewValue(tftypes.Number, 0)
+	values["environment_variables"] = tftypes.NewValue(objectType.AttributeTypes["environment_variables"], []tftypes.Value{})
+	values["build_type"] = tftypes.NewValue(tftypes.String, "dockerfile")
+	values["dockerfile_path"] = tftypes.NewValue(tftypes.String, "Dockerfile")
+	values["default_branch"] = tftypes.NewValue(tftypes.String, "develop")
+	values["auto_deploy"] = tftypes.NewValue(tftypes.Bool, true)
+
+	resp := &fwresource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var followUp *sevallaapitest.Request
+	for _, req := range server.Requests() {
+		if req.Method == http.MethodPut {
+			followUp = &req
+		}
+	}
+	if followUp == nil {
+		t.Fatal("expected the settings to be applied with a follow-up update")
+	}
+	body := string(followUp.Body)
+	for _, want := range []string{
+		`"build_type":"dockerfile"`,
+		`"dockerfile_path":"Dockerfile"`,
+		`"default_branch":"develop"`,
+		`"auto_deploy":true`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %s in the follow-up update, got %s", want, body)
+		}
+	}
+
+	var data ApplicationResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if !data.DockerComposeFile.IsNull() {
+		t.Errorf("expected an unset docker_compose_file to stay null, got %s", data.DockerComposeFile)
+	}
+}
+
+func TestApplicationResourceCreate_WaitForReady(t *testing.T) {
+	ctx := context.Background()
+
+	for _, wait := range []bool{true, false} {
+		t.Run(fmt.Sprintf("wait %t", wait), func(t *testing.T) {
+			r, server := testApplicationStatusServer(t, "deploying", "deployed")
+
+			var schemaResp fwresource.SchemaResponse
+			r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+			objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+			values := testNullValues(objectType)
+			values["company_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.CompanyID)
+			values["display_name"] = tftypes.NewValue(tftypes.String, "my-app")
+			values["deployments_limit"] = tftypes.NewValue(tftypes.Number, 0)
+			values["environment_variables"] = tftypes.NewValue(objectType.AttributeTypes["environment_variables"], []tftypes.Value{})
+			values["wait_for_ready"] = tftypes.NewValue(tftypes.Bool, wait)
+
+			resp := &fwresource.CreateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+			r.Create(ctx, fwresource.CreateRequest{
+				Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+			}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var polls int
+			for _, req := range server.Requests() {
+				if req.Method == http.MethodGet {
+					polls++
+				}
+			}
+			if wait && polls != 2 {
+				t.Errorf("expected the status to be polled until deployed, got %d polls", polls)
+			}
+			if !wait && polls != 0 {
+				t.Errorf("expected no status polls, got %d", polls)
+			}
+		})
+	}
+}
+
+func TestApplicationResourceCreate_SettingsUpdateFails(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	server.HandleJSON(http.MethodPut, "/applications/{id}", http.StatusBadRequest, `{"message":"Invalid settings","status":400}`)
+	r := &ApplicationResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := testNullValues(objectType)
+	values["company_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.CompanyID)
+	values["display_name"] = tftypes.NewValue(tftypes.String, "my-app")
+	values["deployments_limit"] = tftypes.NewValue(tftypes.Number, 0)
+	values["environment_variables"] = tftypes.NewValue(objectType.AttributeTypes["environment_variables"], []tftypes.Value{})
+
+	resp := &fwresource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}, resp)
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected the settings update error, got %v", resp.Diagnostics)
+	}
+
+	// The application exists, so it must be in state for the next apply to replace it rather than create another
+	var data ApplicationResourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if data.ID.ValueString() != sevallaapitest.ApplicationID {
+		t.Errorf("expected the created application to be saved, got id %s", data.ID)
+	}
+}
+
+func TestApplicationResourceCreate_DefaultCompanyID(t *testing.T) {
+	ctx := context.Background()
+
+	create := func(t *testing.T, r *ApplicationResource, companyID tftypes.Value) *fwresource.CreateResponse {
+		t.Helper()
+
+		var schemaResp fwresource.SchemaResponse
+		r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+		objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+		values := testNullValues(objectType)
+		values["company_id"] = companyID
+		values["display_name"] = tftypes.NewValue(tftypes.String, "my-app")
+		values["deployments_limit"] = tftypes.NewValue(tftypes.Number, 0)
+		values["environment_variables"] = tftypes.NewValue(objectType.AttributeTypes["environment_variables"], []tftypes.Value{})
+
+		resp := &fwresource.CreateResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		r.Create(ctx, fwresource.CreateRequest{
+			Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}, resp)
+		return resp
+	}
+
+	t.Run("provider default", func(t *testing.T) {
+		r, server := testApplicationStatusServer(t, "deployed")
+		r.companyID = "default-company"
+
+		resp := create(t, r, tftypes.NewValue(tftypes.String, tftypes.UnknownValue))
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		requests := server.Requests()
+		if len(requests) == 0 || !strings.Contains(string(requests[0].Body), `"company_id":"default-company"`) {
+			t.Errorf("expected the provider's company ID in the create request, got %+v", requests)
+		}
+	})
+
+	t.Run("resource wins", func(t *testing.T) {
+		r, server := testApplicationStatusServer(t, "deployed")
+		r.companyID = "default-company"
+
+		resp := create(t, r, tftypes.NewValue(tftypes.String, sevallaapitest.CompanyID))
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		requests := server.Requests()
+		if len(requests) == 0 || !strings.Contains(string(requests[0].Body), `"company_id":"`+sevallaapitest.CompanyID+`"`) {
+			t.Errorf("expected the resource's company ID in the create request, got %+v", requests)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		r, server := testApplicationStatusServer(t, "deployed")
+
+		resp := create(t, r, tftypes.NewValue(tftypes.String, tftypes.UnknownValue))
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Missing Company ID" {
+			t.Errorf("expected a missing company ID error, got %v", resp.Diagnostics)
+		}
+		if len(server.Requests()) != 0 {
+			t.Errorf("expected no API requests, got %d", len(server.Requests()))
+		}
+	})
+}
+
+func TestApplicationResourceUpdate_RestartTrigger(t *testing.T) {
+	ctx := context.Background()
+
+	update := func(t *testing.T, r *ApplicationResource, stateTrigger, planTrigger string) (ApplicationResourceModel, *fwresource.UpdateResponse) {
+		t.Helper()
+
+		var schemaResp fwresource.SchemaResponse
+		r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+		objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+		value := func(trigger string) tftypes.Value {
+			values := testNullValues(objectType)
+			values["id"] = tftypes.NewValue(tftypes.String, sevallaapitest.ApplicationID)
+			values["display_name"] = tftypes.NewValue(tftypes.String, "my-app")
+			values["deployments_limit"] = tftypes.NewValue(tftypes.Number, 0)
+			values["restart_trigger"] = tftypes.NewValue(tftypes.String, trigger)
+			return tftypes.NewValue(objectType, values)
+		}
+
+		resp := &fwresource.UpdateResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		r.Update(ctx, fwresource.UpdateRequest{
+			Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: value(planTrigger)},
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: value(stateTrigger)},
+		}, resp)
+
+		var data ApplicationResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		return data, resp
+	}
+
+	restarts := func(server *sevallaapitest.Server) []sevallaapitest.Request {
+		var requests []sevallaapitest.Request
+		for _, req := range server.Requests() {
+			if req.Method == http.MethodPost && req.Path == "/applications/deployments" {
+				requests = append(requests, req)
+			}
+		}
+		return requests
+	}
+
+	// deploymentStatuses serves the restart deployment with each status in turn, repeating the last one
+	deploymentStatuses := func(server *sevallaapitest.Server, statuses ...string) *int {
+		var polls int
+		server.Handle(http.MethodGet, "/applications/deployments/{id}", func(w http.ResponseWriter, r *http.Request) {
+			status := statuses[min(polls, len(statuses)-1)]
+			polls++
+			body := strings.Replace(sevallaapitest.ApplicationDeploymentFixture, `"status": "success"`, fmt.Sprintf(`"status": %q`, status), 1)
+			sevallaapitest.JSONResponse(http.StatusOK, body)(w, r)
+		})
+		return &polls
+	}
+
+	t.Run("deployed", func(t *testing.T) {
+		r, server := testApplicationStatusServer(t, "deployed")
+		polls := deploymentStatuses(server, "pending", "running", "success")
+
+		data, resp := update(t, r, "v1", "v2")
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if requests := restarts(server); len(requests) != 1 || !strings.Contains(string(requests[0].Body), `"is_restart":true`) {
+			t.Errorf("expected a single restart, got %+v", requests)
+		}
+		if *polls != 3 {
+			t.Errorf("expected the restart deployment to be polled until it succeeded, got %d polls", *polls)
+		}
+		if last, _ := server.LastRequest(); last.Method != http.MethodGet || last.Path != "/applications/"+sevallaapitest.ApplicationID {
+			t.Errorf("expected the application to be refreshed after the restart, got %s %s", last.Method, last.Path)
+		}
+		if data.RestartTrigger.ValueString() != "v2" || data.Status.ValueString() != "deployed" {
+			t.Errorf("expected trigger v2 and status deployed, got %s and %s", data.RestartTrigger, data.Status)
+		}
+	})
+
+	t.Run("restart failed", func(t *testing.T) {
+		r, server := testApplicationStatusServer(t, "deployed")
+		deploymentStatuses(server, "running", "failed")
+
+		data, resp := update(t, r, "v1", "v2")
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Application Failed" {
+			t.Fatalf("expected an application failed error, got %v", resp.Diagnostics)
+		}
+		if data.RestartTrigger.ValueString() != "v2" {
+			t.Errorf("expected the restart to be recorded, got %s", data.RestartTrigger)
+		}
+	})
+
+	t.Run("not deployed", func(t *testing.T) {
+		r, server := testApplicationStatusServer(t, "deploying")
+		server.HandleJSON(http.MethodPut, "/applications/{id}", http.StatusOK,
+			strings.Replace(sevallaapitest.ApplicationFixture, `"status": "deployed"`, `"status": "deploying"`, 1))
+
+		data, resp := update(t, r, "v1", "v2")
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Application Not Restartable" {
+			t.Fatalf("expected a not restartable error, got %v", resp.Diagnostics)
+		}
+		if requests := restarts(server); len(requests) != 0 {
+			t.Errorf("expected no restart, got %+v", requests)
+		}
+		if data.RestartTrigger.ValueString() != "v1" {
+			t.Errorf("expected the previous trigger to be kept so the restart is retried, got %s", data.RestartTrigger)
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		r, server := testApplicationStatusServer(t, "deployed")
+
+		_, resp := update(t, r, "v1", "v1")
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if requests := restarts(server); len(requests) != 0 {
+			t.Errorf("expected no restart, got %+v", requests)
+		}
+	})
+}
+
+func TestApplicationResourceDelete_DeletionProtection(t *testing.T) {
+	ctx := context.Background()
+
+	for _, protected := range []bool{true, false} {
+		t.Run(fmt.Sprintf("protected %t", protected), func(t *testing.T) {
+			r, server := testApplicationStatusServer(t, "deployed")
+			server.HandleJSON(http.MethodGet, "/applications/{id}", http.StatusNotFound, `{"message":"Application not found","status":404}`)
+
+			var schemaResp fwresource.SchemaResponse
+			r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+			objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+			values := testNullValues(objectType)
+			values["id"] = tftypes.NewValue(tftypes.String, sevallaapitest.ApplicationID)
+			values["deletion_protection"] = tftypes.NewValue(tftypes.Bool, protected)
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+
+			resp := &fwresource.DeleteResponse{State: state}
+			r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+
+			var deletes int
+			for _, req := range server.Requests() {
+				if req.Method == http.MethodDelete {
+					deletes++
+				}
+			}
+			if protected {
+				if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Deletion Protection Enabled" {
+					t.Errorf("expected a deletion protection error, got %v", resp.Diagnostics)
+				}
+				if deletes != 0 {
+					t.Errorf("expected no delete request, got %d", deletes)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if deletes != 1 {
+				t.Errorf("expected a single delete request, got %d", deletes)
+			}
+		})
+	}
+}
+
+func TestApplicationResourceImportState(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	r := &ApplicationResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	importState := func(id string) (ApplicationResourceModel, *fwresource.ImportStateResponse) {
+		resp := &fwresource.ImportStateResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		r.ImportState(ctx, fwresource.ImportStateRequest{ID: id}, resp)
+
+		var data ApplicationResourceModel
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		}
+		return data, resp
+	}
+
+	t.Run("bare application id", func(t *testing.T) {
+		data, resp := importState(sevallaapitest.ApplicationID)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if data.ID.ValueString() != sevallaapitest.ApplicationID || !data.CompanyID.IsNull() {
+			t.Errorf("unexpected id %s and company %s", data.ID, data.CompanyID)
+		}
+		if len(server.Requests()) != 0 {
+			t.Errorf("expected no API requests, got %d", len(server.Requests()))
+		}
+	})
+
+	t.Run("company prefixed", func(t *testing.T) {
+		data, resp := importState("company/" + sevallaapitest.CompanyID + "/application/" + sevallaapitest.ApplicationID)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if data.ID.ValueString() != sevallaapitest.ApplicationID || data.CompanyID.ValueString() != sevallaapitest.CompanyID {
+			t.Errorf("unexpected id %s and company %s", data.ID, data.CompanyID)
+		}
+		if data.DeletionProtection.ValueBool() {
+			t.Error("expected deletion_protection to be false")
+		}
+	})
+
+	t.Run("company and application", func(t *testing.T) {
+		data, resp := importState(sevallaapitest.CompanyID + "/" + sevallaapitest.ApplicationID)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if data.ID.ValueString() != sevallaapitest.ApplicationID || data.CompanyID.ValueString() != sevallaapitest.CompanyID {
+			t.Errorf("unexpected id %s and company %s", data.ID, data.CompanyID)
+		}
+		if !data.WaitForReady.ValueBool() || data.DeletionProtection.ValueBool() {
+			t.Errorf("expected the default wait_for_ready and deletion_protection, got %s and %s", data.WaitForReady, data.DeletionProtection)
+		}
+	})
+
+	t.Run("another company", func(t *testing.T) {
+		for _, id := range []string{"company/company-2/application/" + sevallaapitest.ApplicationID, "company-2/" + sevallaapitest.ApplicationID} {
+			_, resp := importState(id)
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Unexpected Import Identifier" {
+				t.Errorf("expected a company mismatch error for %q, got %v", id, resp.Diagnostics)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, id := range []string{
+			"company/" + sevallaapitest.CompanyID, "company//application/app-1", "company/company-1/database/app-1",
+			"/app-1", "company-1/", "company-1/app-1/extra",
+		} {
+			if _, resp := importState(id); !resp.Diagnostics.HasError() {
+				t.Errorf("expected an invalid import identifier error for %q", id)
+			}
+		}
+	})
+}
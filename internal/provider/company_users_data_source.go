@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -25,7 +26,9 @@ type CompanyUsersDataSource struct {
 // CompanyUsersDataSourceModel describes the data source data model.
 type CompanyUsersDataSourceModel struct {
 	CompanyID types.String                 `tfsdk:"company_id"`
+	Email     types.String                 `tfsdk:"email"`
 	Users     []CompanyUserDataSourceModel `tfsdk:"users"`
+	UserCount types.Int64                  `tfsdk:"user_count"`
 }
 
 // CompanyUserDataSourceModel describes the user data model.
@@ -49,9 +52,13 @@ func (d *CompanyUsersDataSource) Schema(ctx context.Context, req datasource.Sche
 				Required:            true,
 				MarkdownDescription: "The unique identifier of the company.",
 			},
+			"email": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return users with this email address, compared case-insensitively. Returns all users when unset.",
+			},
 			"users": schema.ListNestedAttribute{
 				Computed:            true,
-				MarkdownDescription: "List of users in the company.",
+				MarkdownDescription: "List of users in the company, filtered by `email` when set.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.StringAttribute{
@@ -73,6 +80,10 @@ func (d *CompanyUsersDataSource) Schema(ctx context.Context, req datasource.Sche
 					},
 				},
 			},
+			"user_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of users in `users`.",
+			},
 		},
 	}
 }
@@ -111,6 +122,9 @@ func (d *CompanyUsersDataSource) Read(ctx context.Context, req datasource.ReadRe
 	// Convert API users to terraform model
 	var userModels []CompanyUserDataSourceModel
 	for _, apiUser := range users.Company.Users {
+		if !data.Email.IsNull() && !strings.EqualFold(apiUser.User.Email, data.Email.ValueString()) {
+			continue
+		}
 		userModels = append(userModels, CompanyUserDataSourceModel{
 			ID:       types.StringValue(apiUser.User.ID),
 			Email:    types.StringValue(apiUser.User.Email),
@@ -120,6 +134,7 @@ func (d *CompanyUsersDataSource) Read(ctx context.Context, req datasource.ReadRe
 	}
 
 	data.Users = userModels
+	data.UserCount = types.Int64Value(int64(len(userModels)))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestCompanyUsersDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	d := &CompanyUsersDataSource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	tests := map[string]struct {
+		email     interface{}
+		wantCount int64
+	}{
+		"all users":      {nil, 1},
+		"matching email": {"JANE@example.com", 1},
+		"no match":       {"john@example.com", 0},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := testNullValues(objectType)
+			values["company_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.CompanyID)
+			values["email"] = tftypes.NewValue(tftypes.String, tt.email)
+
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+			}
+			resp := &datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+			d.Read(ctx, req, resp)
+
+			var data CompanyUsersDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if data.UserCount.ValueInt64() != tt.wantCount || int64(len(data.Users)) != tt.wantCount {
+				t.Fatalf("expected %d users, got user_count %s and %d users", tt.wantCount, data.UserCount, len(data.Users))
+			}
+			if tt.wantCount > 0 && data.Users[0].Email.ValueString() != "jane@example.com" {
+				t.Errorf("unexpected user %+v", data.Users[0])
+			}
+		})
+	}
+}
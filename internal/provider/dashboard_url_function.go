@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DashboardURLFunction{}
+
+// dashboardPaths maps the resource types accepted by dashboard_url to their path in the dashboard.
+var dashboardPaths = map[string]string{
+	"application": "apps",
+	"database":    "databases",
+	"static_site": "static-sites",
+	"site":        "sites",
+	"pipeline":    "pipelines",
+}
+
+func NewDashboardURLFunction() function.Function {
+	return &DashboardURLFunction{}
+}
+
+// DashboardURLFunction defines the function implementation.
+type DashboardURLFunction struct{}
+
+func (f *DashboardURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dashboard_url"
+}
+
+func (f *DashboardURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the Sevalla dashboard URL of a resource.",
+		MarkdownDescription: "Returns a link to a resource in the Sevalla dashboard. The dashboard host is derived from the " +
+			"`SEVALLA_BASE_URL` environment variable, replacing a leading `api.` with `app.`, and defaults to " +
+			"`https://app.sevalla.com`. Functions cannot read the provider configuration, so a `base_url` set there is not used.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "resource_type",
+				MarkdownDescription: "The type of resource: one of `" + strings.Join(dashboardResourceTypes(), "`, `") + "`.",
+				Validators: []function.StringParameterValidator{
+					stringvalidator.OneOf(dashboardResourceTypes()...),
+				},
+			},
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "The ID of the resource.",
+				Validators: []function.StringParameterValidator{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DashboardURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var resourceType, id string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &resourceType, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	resourcePath, ok := dashboardPaths[resourceType]
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf(
+			"Unknown resource type %q, expected one of: %s", resourceType, strings.Join(dashboardResourceTypes(), ", ")))
+		return
+	}
+
+	baseURL := sevallaapi.DefaultBaseURL
+	if envBaseURL := os.Getenv("SEVALLA_BASE_URL"); envBaseURL != "" {
+		baseURL = envBaseURL
+	}
+
+	dashboard, err := dashboardURL(baseURL)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Unable to derive the dashboard URL from %q: %s", baseURL, err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, dashboard.JoinPath(resourcePath, id).String()))
+}
+
+// dashboardURL returns the dashboard root for an API base URL, e.g. https://app.sevalla.com for https://api.sevalla.com/v2.
+func dashboardURL(baseURL string) (*url.URL, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("expected an absolute URL")
+	}
+
+	host := parsed.Host
+	if rest, ok := strings.CutPrefix(host, "api."); ok {
+		host = "app." + rest
+	}
+
+	return &url.URL{Scheme: parsed.Scheme, Host: host}, nil
+}
+
+func dashboardResourceTypes() []string {
+	resourceTypes := make([]string, 0, len(dashboardPaths))
+	for resourceType := range dashboardPaths {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testDashboardURL(t *testing.T, resourceType, id string) (string, *function.FuncError) {
+	t.Helper()
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(resourceType), types.StringValue(id)}),
+	}
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	NewDashboardURLFunction().Run(context.Background(), req, resp)
+
+	result, ok := resp.Result.Value().(types.String)
+	if !ok {
+		t.Fatalf("expected a string result, got %T", resp.Result.Value())
+	}
+	return result.ValueString(), resp.Error
+}
+
+func TestDashboardURLFunction(t *testing.T) {
+	tests := map[string]struct {
+		baseURL      string
+		resourceType string
+		want         string
+	}{
+		"default":          {"", "application", "https://app.sevalla.com/apps/app-1"},
+		"static site":      {"", "static_site", "https://app.sevalla.com/static-sites/app-1"},
+		"staging api":      {"https://api.staging.sevalla.com/v2", "database", "https://app.staging.sevalla.com/databases/app-1"},
+		"non-api base url": {"http://localhost:8080/v2", "pipeline", "http://localhost:8080/pipelines/app-1"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("SEVALLA_BASE_URL", tt.baseURL)
+
+			got, err := testDashboardURL(t, tt.resourceType, "app-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDashboardURLFunction_UnknownResourceType(t *testing.T) {
+	t.Setenv("SEVALLA_BASE_URL", "")
+
+	_, err := testDashboardURL(t, "bucket", "bucket-1")
+	if err == nil {
+		t.Fatal("expected an error for an unknown resource type")
+	}
+	if err.FunctionArgument == nil || *err.FunctionArgument != 0 {
+		t.Errorf("expected the error to point at the resource_type argument, got %+v", err)
+	}
+}
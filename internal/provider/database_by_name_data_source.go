@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+var _ datasource.DataSource = &DatabaseByNameDataSource{}
+
+func NewDatabaseByNameDataSource() datasource.DataSource {
+	return &DatabaseByNameDataSource{}
+}
+
+// DatabaseByNameDataSource looks up a database by display name and exposes the same
+// attributes as DatabaseDataSource, so it shares DatabaseDataSourceModel.
+type DatabaseByNameDataSource struct {
+	client *sevallaapi.Client
+}
+
+func (d *DatabaseByNameDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_database_by_name"
+}
+
+func (d *DatabaseByNameDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	var base datasource.SchemaResponse
+	(&DatabaseDataSource{}).Schema(ctx, req, &base)
+
+	attributes := base.Schema.Attributes
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "Identifier of the matching database",
+		Computed:            true,
+	}
+	attributes["company_id"] = schema.StringAttribute{
+		MarkdownDescription: "Company ID whose databases are searched",
+		Required:            true,
+	}
+	attributes["display_name"] = schema.StringAttribute{
+		MarkdownDescription: "Display name of the database to look up",
+		Required:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the database in a company with the given display name. " +
+			"Reading fails unless exactly one database matches.",
+		Attributes: attributes,
+	}
+}
+
+func (d *DatabaseByNameDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(SevallaProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected SevallaProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *DatabaseByNameDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DatabaseDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	companyID, displayName := data.CompanyID.ValueString(), data.DisplayName.ValueString()
+	tflog.Trace(ctx, "reading database by name data source", map[string]interface{}{
+		"company_id":   companyID,
+		"display_name": displayName,
+	})
+
+	databases, err := d.client.Databases.List(ctx, companyID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list databases, got error: %s", err))
+		return
+	}
+
+	var matches []string
+	for _, db := range databases {
+		if db.DisplayName == displayName {
+			matches = append(matches, db.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"Database Not Found",
+			fmt.Sprintf("No database in company %s has display_name %q.", companyID, displayName),
+		)
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddError(
+			"Multiple Databases Found",
+			fmt.Sprintf("%d databases in company %s have display_name %q: %s. Use a unique display name or the sevalla_database data source with an ID.",
+				len(matches), companyID, displayName, strings.Join(matches, ", ")),
+		)
+		return
+	}
+
+	db, err := d.client.Databases.GetWithOptions(ctx, matches[0], databaseDataSourceGetOptions(&data))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read database, got error: %s", err))
+		return
+	}
+
+	mapDatabaseToDataSourceModel(&data, db)
+	// The database response has no company, so keep the one the lookup was scoped to.
+	data.CompanyID = types.StringValue(companyID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
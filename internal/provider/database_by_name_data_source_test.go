@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestDatabaseByNameDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	d := &DatabaseByNameDataSource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	read := func(displayName string) (DatabaseDataSourceModel, *datasource.ReadResponse) {
+		values := testNullValues(objectType)
+		values["company_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.CompanyID)
+		values["display_name"] = tftypes.NewValue(tftypes.String, displayName)
+
+		req := datasource.ReadRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}
+		resp := &datasource.ReadResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		d.Read(ctx, req, resp)
+
+		var data DatabaseDataSourceModel
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		}
+		return data, resp
+	}
+
+	t.Run("match", func(t *testing.T) {
+		data, resp := read("my-db")
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if data.ID.ValueString() != sevallaapitest.DatabaseID || data.Name.ValueString() != "my-db-abc12" ||
+			data.InternalHostname.ValueString() != "my-db-abc12-postgresql.svc.cluster.local" {
+			t.Errorf("unexpected database %s (%s, %s)", data.ID, data.Name, data.InternalHostname)
+		}
+		if data.CompanyID.ValueString() != sevallaapitest.CompanyID {
+			t.Errorf("expected company_id to be kept, got %s", data.CompanyID)
+		}
+
+		req, _ := server.LastRequest()
+		if req.Path != "/databases/db-1" {
+			t.Errorf("expected the matching database to be read, got %s", req.Path)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, resp := read("missing")
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Database Not Found" {
+			t.Errorf("expected a not found error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		server.HandleJSON(http.MethodGet, "/databases", http.StatusOK, `{"company": {"databases": {"items": [
+			{"id": "db-1", "name": "my-db-abc12", "display_name": "my-db", "status": "ready"},
+			{"id": "db-2", "name": "my-db-def34", "display_name": "my-db", "status": "ready"}
+		]}}}`)
+
+		_, resp := read("my-db")
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Multiple Databases Found" {
+			t.Errorf("expected an ambiguity error, got %v", resp.Diagnostics)
+		}
+	})
+}
@@ -21,6 +21,31 @@ type DatabaseDataSource struct {
 	client *sevallaapi.Client
 }
 
+// DatabaseDataSourceModel describes the data source data model.
+type DatabaseDataSourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	DisplayName      types.String `tfsdk:"display_name"`
+	CompanyID        types.String `tfsdk:"company_id"`
+	Location         types.String `tfsdk:"location"`
+	ResourceType     types.String `tfsdk:"resource_type"`
+	Type             types.String `tfsdk:"type"`
+	Version          types.String `tfsdk:"version"`
+	DBName           types.String `tfsdk:"db_name"`
+	DBPassword       types.String `tfsdk:"db_password"`
+	DBUser           types.String `tfsdk:"db_user"`
+	Status           types.String `tfsdk:"status"`
+	InternalHostname types.String `tfsdk:"internal_hostname"`
+	InternalPort     types.String `tfsdk:"internal_port"`
+	ExternalHostname types.String `tfsdk:"external_hostname"`
+	ExternalPort     types.String `tfsdk:"external_port"`
+	MemoryLimit      types.Int64  `tfsdk:"memory_limit"`
+	CPULimit         types.Int64  `tfsdk:"cpu_limit"`
+	StorageSize      types.Int64  `tfsdk:"storage_size"`
+	Cluster          types.Object `tfsdk:"cluster"`
+	IncludeExternal  types.Bool   `tfsdk:"include_external"`
+}
+
 func (d *DatabaseDataSource) Metadata(
 	ctx context.Context,
 	req datasource.MetadataRequest,
@@ -96,12 +121,47 @@ func (d *DatabaseDataSource) Schema(
 				Computed:            true,
 			},
 			"external_hostname": schema.StringAttribute{
-				MarkdownDescription: "External hostname",
+				MarkdownDescription: "External hostname. Null when `include_external` is false",
 				Computed:            true,
 			},
 			"external_port": schema.StringAttribute{
-				MarkdownDescription: "External port",
+				MarkdownDescription: "External port. Null when `include_external` is false",
+				Computed:            true,
+			},
+			"include_external": schema.BoolAttribute{
+				MarkdownDescription: "Whether to fetch the external hostname and port. Defaults to true; " +
+					"set to false for internal-only databases so the API skips computing external endpoints",
+				Optional: true,
+			},
+			"memory_limit": schema.Int64Attribute{
+				MarkdownDescription: "Memory limit",
+				Computed:            true,
+			},
+			"cpu_limit": schema.Int64Attribute{
+				MarkdownDescription: "CPU limit",
+				Computed:            true,
+			},
+			"storage_size": schema.Int64Attribute{
+				MarkdownDescription: "Storage size",
+				Computed:            true,
+			},
+			"cluster": schema.SingleNestedAttribute{
+				MarkdownDescription: "Cluster the database runs on",
 				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						MarkdownDescription: "Cluster ID",
+						Computed:            true,
+					},
+					"location": schema.StringAttribute{
+						MarkdownDescription: "Cluster location",
+						Computed:            true,
+					},
+					"display_name": schema.StringAttribute{
+						MarkdownDescription: "Cluster display name",
+						Computed:            true,
+					},
+				},
 			},
 		},
 	}
@@ -130,7 +190,7 @@ func (d *DatabaseDataSource) Configure(
 }
 
 func (d *DatabaseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data DatabaseResourceModel
+	var data DatabaseDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -139,13 +199,28 @@ func (d *DatabaseDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	tflog.Trace(ctx, "reading database data source")
 
-	db, err := d.client.Databases.Get(ctx, data.ID.ValueString())
+	db, err := d.client.Databases.GetWithOptions(ctx, data.ID.ValueString(), databaseDataSourceGetOptions(&data))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read database, got error: %s", err))
 		return
 	}
 
-	// Map API response to model
+	mapDatabaseToDataSourceModel(&data, db)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// databaseDataSourceGetOptions returns the connection details to request for a data source
+// read. External details are fetched unless include_external is false.
+func databaseDataSourceGetOptions(data *DatabaseDataSourceModel) sevallaapi.GetDatabaseOptions {
+	return sevallaapi.GetDatabaseOptions{
+		Internal: true,
+		External: data.IncludeExternal.IsNull() || data.IncludeExternal.ValueBool(),
+	}
+}
+
+// mapDatabaseToDataSourceModel copies a database API response into the data source model.
+func mapDatabaseToDataSourceModel(data *DatabaseDataSourceModel, db *sevallaapi.Database) {
 	data.ID = types.StringValue(db.Database.ID)
 	data.Name = types.StringValue(db.Database.Name)
 	data.DisplayName = types.StringValue(db.Database.DisplayName)
@@ -160,6 +235,10 @@ func (d *DatabaseDataSource) Read(ctx context.Context, req datasource.ReadReques
 		data.DBUser = types.StringValue(*db.Database.Data.DBUser)
 	}
 	data.Status = types.StringValue(db.Database.Status)
+	data.MemoryLimit = types.Int64Value(int64(db.Database.MemoryLimit))
+	data.CPULimit = types.Int64Value(int64(db.Database.CPULimit))
+	data.StorageSize = types.Int64Value(int64(db.Database.StorageSize))
+	data.Cluster = databaseClusterObjectValue(db.Database.Cluster)
 
 	if db.Database.InternalHostname != nil {
 		data.InternalHostname = types.StringValue(*db.Database.InternalHostname)
@@ -173,6 +252,4 @@ func (d *DatabaseDataSource) Read(ctx context.Context, req datasource.ReadReques
 	if db.Database.ExternalPort != nil {
 		data.ExternalPort = types.StringValue(*db.Database.ExternalPort)
 	}
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestDatabaseDataSourceRead_IncludeExternal(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	d := &DatabaseDataSource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	tests := map[string]struct {
+		includeExternal interface{}
+		wantExternal    string
+	}{
+		"default":  {nil, "true"},
+		"included": {true, "true"},
+		"excluded": {false, "false"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := testNullValues(objectType)
+			values["id"] = tftypes.NewValue(tftypes.String, sevallaapitest.DatabaseID)
+			values["include_external"] = tftypes.NewValue(tftypes.Bool, tt.includeExternal)
+
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+			}
+			resp := &datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+			d.Read(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			sent, _ := server.LastRequest()
+			if sent.Query.Get("internal") != "true" || sent.Query.Get("external") != tt.wantExternal {
+				t.Errorf("expected internal=true&external=%s, got %s", tt.wantExternal, sent.Query.Encode())
+			}
+		})
+	}
+}
@@ -2,12 +2,21 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -19,6 +28,34 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &DatabaseResource{}
 var _ resource.ResourceWithImportState = &DatabaseResource{}
+var _ resource.ResourceWithValidateConfig = &DatabaseResource{}
+
+const (
+	defaultDatabaseCreateTimeout = 20 * time.Minute
+	defaultDatabaseDeleteTimeout = 10 * time.Minute
+)
+
+// databaseClusterAttrTypes describes the object type of a database's cluster in state.
+var databaseClusterAttrTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"location":     types.StringType,
+	"display_name": types.StringType,
+}
+
+// databaseVersions lists the versions Sevalla offers for each database type, newest last.
+// Update it here as Sevalla adds or retires versions.
+var databaseVersions = map[string][]string{
+	"postgresql": {"12", "13", "14", "15", "16", "17"},
+	"mysql":      {"8"},
+	"mariadb":    {"10", "11"},
+	"redis":      {"6", "7"},
+}
+
+// errDatabaseFailed is returned when a new database ends up in the failed status.
+var errDatabaseFailed = errors.New("database failed")
+
+// databasePollInterval is how often database status and deletion are polled. Tests shorten it.
+var databasePollInterval = 5 * time.Second
 
 func NewDatabaseResource() resource.Resource {
 	return &DatabaseResource{}
@@ -26,27 +63,38 @@ func NewDatabaseResource() resource.Resource {
 
 // DatabaseResource defines the resource implementation.
 type DatabaseResource struct {
-	client *sevallaapi.Client
+	client    *sevallaapi.Client
+	companyID string
 }
 
 // DatabaseResourceModel describes the resource data model.
 type DatabaseResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Name             types.String `tfsdk:"name"`
-	DisplayName      types.String `tfsdk:"display_name"`
-	CompanyID        types.String `tfsdk:"company_id"`
-	Location         types.String `tfsdk:"location"`
-	ResourceType     types.String `tfsdk:"resource_type"`
-	Type             types.String `tfsdk:"type"`
-	Version          types.String `tfsdk:"version"`
-	DBName           types.String `tfsdk:"db_name"`
-	DBPassword       types.String `tfsdk:"db_password"`
-	DBUser           types.String `tfsdk:"db_user"`
-	Status           types.String `tfsdk:"status"`
-	InternalHostname types.String `tfsdk:"internal_hostname"`
-	InternalPort     types.String `tfsdk:"internal_port"`
-	ExternalHostname types.String `tfsdk:"external_hostname"`
-	ExternalPort     types.String `tfsdk:"external_port"`
+	ID                       types.String   `tfsdk:"id"`
+	Name                     types.String   `tfsdk:"name"`
+	DisplayName              types.String   `tfsdk:"display_name"`
+	CompanyID                types.String   `tfsdk:"company_id"`
+	Location                 types.String   `tfsdk:"location"`
+	ResourceType             types.String   `tfsdk:"resource_type"`
+	Type                     types.String   `tfsdk:"type"`
+	Version                  types.String   `tfsdk:"version"`
+	DBName                   types.String   `tfsdk:"db_name"`
+	DBPassword               types.String   `tfsdk:"db_password"`
+	DBUser                   types.String   `tfsdk:"db_user"`
+	DBRootPassword           types.String   `tfsdk:"db_root_password"`
+	Status                   types.String   `tfsdk:"status"`
+	MemoryLimit              types.Int64    `tfsdk:"memory_limit"`
+	CPULimit                 types.Int64    `tfsdk:"cpu_limit"`
+	StorageSize              types.Int64    `tfsdk:"storage_size"`
+	Cluster                  types.Object   `tfsdk:"cluster"`
+	InternalHostname         types.String   `tfsdk:"internal_hostname"`
+	InternalPort             types.String   `tfsdk:"internal_port"`
+	ExternalHostname         types.String   `tfsdk:"external_hostname"`
+	ExternalPort             types.String   `tfsdk:"external_port"`
+	ExternalConnectionString types.String   `tfsdk:"external_connection_string"`
+	RequireSSL               types.Bool     `tfsdk:"require_ssl"`
+	WaitForActive            types.Bool     `tfsdk:"wait_for_active"`
+	DeletionProtection       types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts                 timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *DatabaseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -55,7 +103,9 @@ func (r *DatabaseResource) Metadata(ctx context.Context, req resource.MetadataRe
 
 func (r *DatabaseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages a database on Sevalla platform.",
+		MarkdownDescription: "Manages a database on Sevalla platform. " +
+			"Import it with `database_id`, `company_id/database_id` or `company/company_id/database/database_id`; " +
+			"the last two also set `company_id`.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -74,48 +124,113 @@ func (r *DatabaseResource) Schema(ctx context.Context, req resource.SchemaReques
 				MarkdownDescription: "The display name of the database.",
 			},
 			"company_id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The company ID that owns this database.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The company ID that owns this database. Defaults to the provider's `company_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"location": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "The location where the database will be created (e.g., us-central1, europe-west3).",
+				MarkdownDescription: "The location where the database will be created (e.g., us-central1, europe-west3). Changing this forces a new database.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"resource_type": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "The resource type for the database (db1, db2, ..., db9).",
+				MarkdownDescription: "The resource type for the database (db1, db2, ..., db9). Changing this resizes the database in place.",
 				Validators: []validator.String{
 					stringvalidator.OneOf("db1", "db2", "db3", "db4", "db5", "db6", "db7", "db8", "db9"),
 				},
 			},
 			"type": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "The database type (postgresql, redis, mariadb, mysql).",
+				MarkdownDescription: "The database type (postgresql, redis, mariadb, mysql). Changing this forces a new database.",
 				Validators: []validator.String{
 					stringvalidator.OneOf("postgresql", "redis", "mariadb", "mysql"),
 				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"version": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "The database version.",
+				MarkdownDescription: "The database version, such as `16` for PostgreSQL or `7` for Redis. Changing this forces a new database.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"db_name": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "The database name.",
+				MarkdownDescription: "The database name. Changing this forces a new database.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"db_password": schema.StringAttribute{
 				Required:            true,
 				Sensitive:           true,
-				MarkdownDescription: "The database password.",
+				MarkdownDescription: "The database password. Changing this forces a new database.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"db_user": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "The database user (optional for Redis, required for others).",
+				Computed:            true,
+				MarkdownDescription: "The database user. Required for every type except Redis, which has no user. Changing this forces a new database.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"db_root_password": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The root password generated for MySQL and MariaDB databases. Null for database types without a root user, such as Redis.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The current status of the database.",
 			},
+			"memory_limit": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The memory limit of the database, determined by `resource_type`.",
+			},
+			"cpu_limit": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The CPU limit of the database, determined by `resource_type`.",
+			},
+			"storage_size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The storage size of the database, determined by `resource_type`.",
+			},
+			"cluster": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The cluster the database runs on.",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The cluster ID.",
+					},
+					"location": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The location of the cluster.",
+					},
+					"display_name": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The display name of the cluster.",
+					},
+				},
+			},
 			"internal_hostname": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The internal hostname for database connections.",
@@ -132,10 +247,77 @@ func (r *DatabaseResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:            true,
 				MarkdownDescription: "The external port for database connections.",
 			},
+			"wait_for_active": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				MarkdownDescription: "Whether to wait after creation until the database is active and accepts connections, " +
+					"so resources that depend on it can connect right away. The wait counts against the create timeout. Defaults to true.",
+			},
+			"deletion_protection": deletionProtectionAttribute("database"),
+			"external_connection_string": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				MarkdownDescription: "The connection URL for reaching the database from outside Sevalla, including the credentials. " +
+					"Includes the TLS parameters for the engine when `require_ssl` is set. Null while external access is disabled.",
+			},
+			"require_ssl": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Whether `external_connection_string` requires TLS: `sslmode=require` for PostgreSQL, " +
+					"`ssl-mode=REQUIRED` for MySQL and MariaDB, and the `rediss` scheme for Redis. Defaults to `false`.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+func (r *DatabaseResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data DatabaseResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Type.IsUnknown() || data.Type.IsNull() {
+		return
+	}
+
+	if versions, ok := databaseVersions[data.Type.ValueString()]; ok && !data.Version.IsUnknown() && !data.Version.IsNull() &&
+		!slices.Contains(versions, data.Version.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("version"),
+			"Unsupported Database Version",
+			fmt.Sprintf("Version %q is not available for %s databases. Valid versions are: %s.",
+				data.Version.ValueString(), data.Type.ValueString(), strings.Join(versions, ", ")),
+		)
+	}
+
+	if data.DBUser.IsUnknown() {
+		return
+	}
+
+	hasUser := data.DBUser.ValueString() != ""
+	switch {
+	case data.Type.ValueString() != "redis" && !hasUser:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("db_user"),
+			"Missing Database User",
+			fmt.Sprintf("db_user must be set for %s databases. Only Redis databases can be created without a user.", data.Type.ValueString()),
+		)
+	case data.Type.ValueString() == "redis" && hasUser:
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("db_user"),
+			"Database User Not Used",
+			"Redis databases do not have a user, so db_user is not needed and may not be reported back by the API.",
+		)
+	}
+}
+
 func (r *DatabaseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -151,6 +333,7 @@ func (r *DatabaseResource) Configure(ctx context.Context, req resource.Configure
 	}
 
 	r.client = data.Client
+	r.companyID = data.CompanyID
 }
 
 func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -161,6 +344,12 @@ func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	data.CompanyID = companyIDOrDefault(data.CompanyID, r.companyID)
+	if data.CompanyID.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("company_id"), "Missing Company ID", missingCompanyIDDetail)
+		return
+	}
+
 	createReq := sevallaapi.CreateDatabaseRequest{
 		CompanyID:    data.CompanyID.ValueString(),
 		Location:     data.Location.ValueString(),
@@ -176,6 +365,7 @@ func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateReques
 		createReq.DBUser = data.DBUser.ValueString()
 	}
 
+	ctx = maskSecrets(ctx, createReq.DBPassword)
 	tflog.Debug(ctx, "Creating database", map[string]interface{}{
 		"company_id":    createReq.CompanyID,
 		"display_name":  createReq.DisplayName,
@@ -185,43 +375,83 @@ func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateReques
 		"resource_type": createReq.ResourceType,
 	})
 
-	db, err := r.client.Databases.Create(ctx, createReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create database, got error: %s", err))
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultDatabaseCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data.ID = types.StringValue(db.Database.ID)
-	data.Name = types.StringValue(db.Database.Name)
-	data.DisplayName = types.StringValue(db.Database.DisplayName)
-	data.Status = types.StringValue(db.Database.Status)
-	data.Type = types.StringValue(db.Database.Type)
-	data.Version = types.StringValue(db.Database.Version)
+	createCtx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	if db.Database.InternalHostname != nil {
-		data.InternalHostname = types.StringValue(*db.Database.InternalHostname)
-	} else {
-		data.InternalHostname = types.StringNull()
+	db, err := r.client.Databases.Create(createCtx, createReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create database, got error: %s", err))
+		return
 	}
-	if db.Database.InternalPort != nil {
-		data.InternalPort = types.StringValue(*db.Database.InternalPort)
-	} else {
-		data.InternalPort = types.StringNull()
+
+	if data.WaitForActive.ValueBool() {
+		var active *sevallaapi.Database
+		active, err = r.waitForActive(createCtx, db.Database.ID)
+		if active != nil {
+			db = active
+		}
 	}
-	if db.Database.ExternalHostname != nil {
-		data.ExternalHostname = types.StringValue(*db.Database.ExternalHostname)
-	} else {
-		data.ExternalHostname = types.StringNull()
+
+	mapDatabaseToModel(&data, &db.Database)
+
+	// Save the database even if it did not become active so it is tainted and recreated on the next apply
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if errors.Is(err, errDatabaseFailed) {
+		resp.Diagnostics.AddError(
+			"Database Creation Failed",
+			fmt.Sprintf("Database %s was created but its status is %q. Check the database in the Sevalla dashboard.",
+				data.ID.ValueString(), db.Database.Status),
+		)
+		return
 	}
-	if db.Database.ExternalPort != nil {
-		data.ExternalPort = types.StringValue(*db.Database.ExternalPort)
-	} else {
-		data.ExternalPort = types.StringNull()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for database to become active, got error: %s", err))
+		return
 	}
 
 	tflog.Trace(ctx, "Created database resource")
+}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// waitForActive polls the database until it is active or failed. It returns errDatabaseFailed
+// together with the database if it failed.
+func (r *DatabaseResource) waitForActive(ctx context.Context, id string) (*sevallaapi.Database, error) {
+	ctx, cancel := r.client.WithDeadline(ctx)
+	defer cancel()
+
+	ticker := time.NewTicker(databasePollInterval)
+	defer ticker.Stop()
+
+	for {
+		db, err := r.client.Databases.Get(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get database status: %w", err)
+		}
+
+		tflog.Debug(ctx, "Polled database status", map[string]interface{}{
+			"id":     id,
+			"status": db.Database.Status,
+		})
+
+		switch sevallaapi.DatabaseStatus(db.Database.Status) {
+		case sevallaapi.DatabaseStatusActive, sevallaapi.DatabaseStatusReady:
+			return db, nil
+		case sevallaapi.DatabaseStatusFailed:
+			return db, errDatabaseFailed
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return db, fmt.Errorf("database is still %q: %w", db.Database.Status, context.Cause(ctx))
+		}
+	}
 }
 
 func (r *DatabaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -238,33 +468,7 @@ func (r *DatabaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	data.ID = types.StringValue(db.Database.ID)
-	data.Name = types.StringValue(db.Database.Name)
-	data.DisplayName = types.StringValue(db.Database.DisplayName)
-	data.Status = types.StringValue(db.Database.Status)
-	data.Type = types.StringValue(db.Database.Type)
-	data.Version = types.StringValue(db.Database.Version)
-
-	if db.Database.InternalHostname != nil {
-		data.InternalHostname = types.StringValue(*db.Database.InternalHostname)
-	} else {
-		data.InternalHostname = types.StringNull()
-	}
-	if db.Database.InternalPort != nil {
-		data.InternalPort = types.StringValue(*db.Database.InternalPort)
-	} else {
-		data.InternalPort = types.StringNull()
-	}
-	if db.Database.ExternalHostname != nil {
-		data.ExternalHostname = types.StringValue(*db.Database.ExternalHostname)
-	} else {
-		data.ExternalHostname = types.StringNull()
-	}
-	if db.Database.ExternalPort != nil {
-		data.ExternalPort = types.StringValue(*db.Database.ExternalPort)
-	} else {
-		data.ExternalPort = types.StringNull()
-	}
+	mapDatabaseToModel(&data, &db.Database)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -277,6 +481,8 @@ func (r *DatabaseResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	data.CompanyID = companyIDOrDefault(data.CompanyID, r.companyID)
+
 	updateReq := sevallaapi.UpdateDatabaseRequest{
 		DisplayName: stringPointer(data.DisplayName.ValueString()),
 	}
@@ -285,16 +491,20 @@ func (r *DatabaseResource) Update(ctx context.Context, req resource.UpdateReques
 		updateReq.ResourceType = stringPointer(data.ResourceType.ValueString())
 	}
 
+	tflog.Debug(ctx, "Updating database", map[string]interface{}{
+		"id":            data.ID.ValueString(),
+		"display_name":  data.DisplayName.ValueString(),
+		"resource_type": data.ResourceType.ValueString(),
+	})
+
+	// Update re-reads the database, so a resize is reflected in the memory, CPU and storage limits
 	db, err := r.client.Databases.Update(ctx, data.ID.ValueString(), updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update database, got error: %s", err))
 		return
 	}
 
-	data.ID = types.StringValue(db.Database.ID)
-	data.Name = types.StringValue(db.Database.Name)
-	data.DisplayName = types.StringValue(db.Database.DisplayName)
-	data.Status = types.StringValue(db.Database.Status)
+	mapDatabaseToModel(&data, &db.Database)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -307,13 +517,133 @@ func (r *DatabaseResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	err := r.client.Databases.Delete(ctx, data.ID.ValueString())
+	if !checkDeletionProtection(data.DeletionProtection, "database", data.ID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDatabaseDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := deleteRetryingConflicts(ctx, deleteConflictRetryInterval, deleteTimeout, func(ctx context.Context) error {
+		return r.client.Databases.Delete(ctx, data.ID.ValueString())
+	})
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete database, got error: %s", err))
 		return
 	}
+
+	deleteCtx, cancel := r.client.WithDeadline(ctx)
+	defer cancel()
+
+	err = waitForDeletion(deleteCtx, databasePollInterval, deleteTimeout, func(ctx context.Context) error {
+		_, err := r.client.Databases.Get(ctx, data.ID.ValueString())
+		return err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for database deletion, got error: %s", err))
+		return
+	}
 }
 
+// ImportState accepts either company_id/database_id or a bare database ID. The company is not
+// part of the API response, so with a bare ID company_id stays null until it is configured.
+// Read fills in the remaining attributes after import.
 func (r *DatabaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	companyID, databaseID, ok := parseCompanyScopedImportID(req.ID, "database")
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: company_id/database_id, database_id or "+
+				"company/company_id/database/database_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	db, err := r.client.Databases.Get(ctx, databaseID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read database %s for import, got error: %s", databaseID, err))
+		return
+	}
+
+	// These are only sent on create, so Read does not refresh them
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), db.Database.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("location"), db.Database.Cluster.Location)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_type"), db.Database.ResourceTypeName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("db_password"), db.Database.Data.DBPassword)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_active"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("require_ssl"), false)...)
+	if companyID != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("company_id"), companyID)...)
+	}
+}
+
+// mapDatabaseToModel maps API response to Terraform model
+func mapDatabaseToModel(data *DatabaseResourceModel, db *sevallaapi.DatabaseDetails) {
+	data.ID = types.StringValue(db.ID)
+	data.Name = types.StringValue(db.Name)
+	data.DisplayName = types.StringValue(db.DisplayName)
+	data.Status = types.StringValue(db.Status)
+	data.Type = types.StringValue(db.Type)
+	data.Version = types.StringValue(db.Version)
+	data.MemoryLimit = types.Int64Value(int64(db.MemoryLimit))
+	data.CPULimit = types.Int64Value(int64(db.CPULimit))
+	data.StorageSize = types.Int64Value(int64(db.StorageSize))
+	data.Cluster = databaseClusterObjectValue(db.Cluster)
+	data.DBName = types.StringValue(db.Data.DBName)
+	data.DBUser = types.StringPointerValue(db.Data.DBUser)
+	data.DBRootPassword = types.StringPointerValue(db.Data.DBRootPassword)
+	data.InternalHostname = types.StringPointerValue(db.InternalHostname)
+	data.InternalPort = types.StringPointerValue(db.InternalPort)
+	data.ExternalHostname = types.StringPointerValue(db.ExternalHostname)
+	data.ExternalPort = types.StringPointerValue(db.ExternalPort)
+
+	if db.ExternalConnectionString != "" {
+		connectionString := db.ExternalConnectionString
+		if data.RequireSSL.ValueBool() {
+			connectionString = withTLSParams(connectionString, db.Type)
+		}
+		data.ExternalConnectionString = types.StringValue(connectionString)
+	} else {
+		data.ExternalConnectionString = types.StringNull()
+	}
+}
+
+// withTLSParams adds the parameters that make clients of the given database type connect with TLS
+// to a connection URL. URLs that cannot be parsed and unknown types are returned unchanged.
+func withTLSParams(connectionString, dbType string) string {
+	u, err := url.Parse(connectionString)
+	if err != nil {
+		return connectionString
+	}
+
+	query := u.Query()
+	switch dbType {
+	case "postgresql":
+		query.Set("sslmode", "require")
+	case "mysql", "mariadb":
+		query.Set("ssl-mode", "REQUIRED")
+	case "redis":
+		u.Scheme = "rediss"
+	default:
+		return connectionString
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// databaseClusterObjectValue converts an API database cluster into its state object.
+func databaseClusterObjectValue(cluster sevallaapi.DatabaseCluster) types.Object {
+	obj, _ := types.ObjectValue(
+		databaseClusterAttrTypes,
+		map[string]attr.Value{
+			"id":           types.StringValue(cluster.ID),
+			"location":     types.StringValue(cluster.Location),
+			"display_name": types.StringValue(cluster.DisplayName),
+		},
+	)
+	return obj
 }
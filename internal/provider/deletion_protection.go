@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute returns the deletion_protection argument of resources that are
+// costly to lose, such as databases.
+func deletionProtectionAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+		MarkdownDescription: fmt.Sprintf("Whether Terraform refuses to destroy the %s, including when a change forces "+
+			"its replacement. To destroy it, set this to `false` and apply first. Defaults to `false`.", kind),
+	}
+}
+
+// checkDeletionProtection adds an error and returns false if the resource is protected from deletion.
+func checkDeletionProtection(protected types.Bool, kind, id string, diags *diag.Diagnostics) bool {
+	if !protected.ValueBool() {
+		return true
+	}
+	diags.AddError(
+		"Deletion Protection Enabled",
+		fmt.Sprintf("The %s %s has deletion_protection enabled, so it was not deleted. "+
+			"Set deletion_protection = false and apply, then destroy it again.", kind, id),
+	)
+	return false
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DeploymentDataSource{}
+
+func NewDeploymentDataSource() datasource.DataSource {
+	return &DeploymentDataSource{}
+}
+
+// DeploymentDataSource defines the data source implementation.
+type DeploymentDataSource struct {
+	client *sevallaapi.Client
+}
+
+// DeploymentDataSourceModel describes the data source data model.
+type DeploymentDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	AppID         types.String `tfsdk:"app_id"`
+	Status        types.String `tfsdk:"status"`
+	Branch        types.String `tfsdk:"branch"`
+	CommitHash    types.String `tfsdk:"commit_hash"`
+	CommitMessage types.String `tfsdk:"commit_message"`
+	CreatedAt     types.Int64  `tfsdk:"created_at"`
+	UpdatedAt     types.Int64  `tfsdk:"updated_at"`
+	Duration      types.Int64  `tfsdk:"duration_seconds"`
+	BuildLogs     types.String `tfsdk:"build_logs"`
+}
+
+func (d *DeploymentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment"
+}
+
+func (d *DeploymentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches a single deployment of a Sevalla application, including its build logs. " +
+			"Only deployments in the application's recent deployment history can be read.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the deployment.",
+			},
+			"app_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the application the deployment belongs to.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the deployment.",
+			},
+			"branch": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The git branch that was deployed.",
+			},
+			"commit_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The hash of the commit that was deployed.",
+			},
+			"commit_message": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The message of the commit that was deployed. Null if the API did not report one.",
+			},
+			"created_at": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp when the deployment was created.",
+			},
+			"updated_at": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp when the deployment was last updated. Null if the API did not report one.",
+			},
+			"duration_seconds": schema.Int64Attribute{
+				Computed: true,
+				MarkdownDescription: "How long a successful deployment took, in seconds, from `created_at` to `updated_at`. " +
+					"Null while the deployment is running, when it did not succeed or when the API did not report `updated_at`. " +
+					"The API does not report when a deployment left the queue, so queue time is included.",
+			},
+			"build_logs": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The build logs of the deployment.",
+			},
+		},
+	}
+}
+
+func (d *DeploymentDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(SevallaProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected SevallaProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *DeploymentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DeploymentDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Build logs are only reported in the application's deployment history
+	app, err := d.client.Applications.Get(ctx, data.AppID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read application, got error: %s", err))
+		return
+	}
+
+	deployment := findAppDeployment(app.App.Deployments, data.ID.ValueString())
+	if deployment == nil {
+		resp.Diagnostics.AddError(
+			"Deployment Not Found",
+			fmt.Sprintf("Deployment %s is not in the deployment history of application %s.",
+				data.ID.ValueString(), data.AppID.ValueString()),
+		)
+		return
+	}
+
+	data.Status = types.StringValue(deployment.Status)
+	data.Branch = types.StringValue(deployment.Branch)
+	data.CommitHash = types.StringValue(deployment.CommitHash)
+	data.CommitMessage = types.StringPointerValue(deployment.CommitMessage)
+	data.CreatedAt = types.Int64Value(deployment.CreatedAt)
+	data.UpdatedAt = types.Int64Null()
+	if deployment.UpdatedAt != 0 {
+		data.UpdatedAt = types.Int64Value(deployment.UpdatedAt)
+	}
+	data.Duration = deploymentDurationSeconds(deployment)
+	data.BuildLogs = types.StringValue(deployment.BuildLogs)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// deploymentDurationSeconds returns how long a successful deployment took. The API reports
+// timestamps in milliseconds and has no separate start or finish time for application
+// deployments, so the duration runs from creation to the last update.
+func deploymentDurationSeconds(deployment *sevallaapi.AppDeployment) types.Int64 {
+	if !slices.Contains(defaultDeploymentSuccessStatuses, deployment.Status) ||
+		deployment.UpdatedAt == 0 || deployment.UpdatedAt < deployment.CreatedAt {
+		return types.Int64Null()
+	}
+	return types.Int64Value((deployment.UpdatedAt - deployment.CreatedAt) / 1000)
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestDeploymentDurationSeconds(t *testing.T) {
+	tests := map[string]struct {
+		deployment sevallaapi.AppDeployment
+		want       types.Int64
+	}{
+		"successful":    {sevallaapi.AppDeployment{Status: "successful", CreatedAt: 1000, UpdatedAt: 62500}, types.Int64Value(61)},
+		"success":       {sevallaapi.AppDeployment{Status: "success", CreatedAt: 1000, UpdatedAt: 1000}, types.Int64Value(0)},
+		"running":       {sevallaapi.AppDeployment{Status: "running", CreatedAt: 1000, UpdatedAt: 62500}, types.Int64Null()},
+		"failed":        {sevallaapi.AppDeployment{Status: "failed", CreatedAt: 1000, UpdatedAt: 62500}, types.Int64Null()},
+		"no updated_at": {sevallaapi.AppDeployment{Status: "successful", CreatedAt: 1000}, types.Int64Null()},
+		"out of order":  {sevallaapi.AppDeployment{Status: "successful", CreatedAt: 62500, UpdatedAt: 1000}, types.Int64Null()},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := deploymentDurationSeconds(&tt.deployment); !got.Equal(tt.want) {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDeploymentDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	d := &DeploymentDataSource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	read := func(deploymentID string) *datasource.ReadResponse {
+		values := testNullValues(objectType)
+		values["app_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.ApplicationID)
+		values["id"] = tftypes.NewValue(tftypes.String, deploymentID)
+
+		req := datasource.ReadRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}
+		resp := &datasource.ReadResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		d.Read(ctx, req, resp)
+		return resp
+	}
+
+	t.Run("found", func(t *testing.T) {
+		resp := read(sevallaapitest.DeploymentID)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var data DeploymentDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if data.Status.ValueString() != "successful" || data.CommitHash.ValueString() != "a1b2c3d" {
+			t.Errorf("unexpected deployment status %s and commit %s", data.Status, data.CommitHash)
+		}
+		if data.BuildLogs.ValueString() != "Build succeeded" {
+			t.Errorf("expected the build logs, got %s", data.BuildLogs)
+		}
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		resp := read(sevallaapitest.DeploymentID)
+		var data DeploymentDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if data.UpdatedAt.ValueInt64() != 1695300725620 || data.Duration.ValueInt64() != 95 {
+			t.Errorf("expected a 95 second deployment, got updated_at %s and duration %s", data.UpdatedAt, data.Duration)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		resp := read("missing")
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Deployment Not Found" {
+			t.Errorf("expected a deployment not found error, got %v", resp.Diagnostics)
+		}
+	})
+}
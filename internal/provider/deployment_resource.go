@@ -0,0 +1,501 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DeploymentResource{}
+var _ resource.ResourceWithValidateConfig = &DeploymentResource{}
+var _ resource.ResourceWithImportState = &DeploymentResource{}
+
+const defaultDeploymentCreateTimeout = 30 * time.Minute
+
+// deploymentPollInterval is how often deployment status is polled. Tests shorten it.
+var deploymentPollInterval = 10 * time.Second
+
+// errDeploymentFailed is returned when a deployment finishes without succeeding.
+var errDeploymentFailed = errors.New("deployment did not succeed")
+
+// knownDeploymentStatuses lists every DeploymentStatus value. Configured terminal statuses must be one of these.
+var knownDeploymentStatuses = []string{
+	string(sevallaapi.DeploymentStatusPending),
+	string(sevallaapi.DeploymentStatusRunning),
+	string(sevallaapi.DeploymentStatusSuccessful),
+	string(sevallaapi.DeploymentStatusFailed),
+	string(sevallaapi.DeploymentStatusCanceled),
+	string(sevallaapi.DeploymentStatusSuccess),
+	string(sevallaapi.DeploymentStatusCancelled),
+}
+
+// Terminal statuses used by the deployment wait unless configured otherwise.
+var (
+	defaultDeploymentSuccessStatuses = []string{
+		string(sevallaapi.DeploymentStatusSuccessful),
+		string(sevallaapi.DeploymentStatusSuccess),
+	}
+	defaultDeploymentFailureStatuses = []string{
+		string(sevallaapi.DeploymentStatusFailed),
+		string(sevallaapi.DeploymentStatusCanceled),
+		string(sevallaapi.DeploymentStatusCancelled),
+	}
+)
+
+// deploymentTerminalStatuses are the statuses that end the deployment wait.
+type deploymentTerminalStatuses struct {
+	Success []string
+	Failure []string
+}
+
+func NewDeploymentResource() resource.Resource {
+	return &DeploymentResource{}
+}
+
+// DeploymentResource defines the resource implementation.
+type DeploymentResource struct {
+	client *sevallaapi.Client
+}
+
+// DeploymentResourceModel describes the resource data model.
+type DeploymentResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	AppID           types.String   `tfsdk:"app_id"`
+	Branch          types.String   `tfsdk:"branch"`
+	DockerImage     types.String   `tfsdk:"docker_image"`
+	IsRestart       types.Bool     `tfsdk:"is_restart"`
+	Triggers        types.Map      `tfsdk:"triggers"`
+	SuccessStatuses types.Set      `tfsdk:"success_statuses"`
+	FailureStatuses types.Set      `tfsdk:"failure_statuses"`
+	Status          types.String   `tfsdk:"status"`
+	CommitHash      types.String   `tfsdk:"commit_hash"`
+	CommitMessage   types.String   `tfsdk:"commit_message"`
+	CreatedAt       types.Int64    `tfsdk:"created_at"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *DeploymentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment"
+}
+
+func (r *DeploymentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Triggers a deployment of a Sevalla application and waits for it to finish. " +
+			"Changing any argument triggers a new deployment. Destroying the resource only removes it from state. " +
+			"Import with `app_id/deployment_id`; the API does not record `branch`, `docker_image` or `triggers`, " +
+			"so they are null after import.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier of the deployment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"app_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the application to deploy.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"branch": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The git branch to deploy. Defaults to the application's default branch.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"docker_image": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The Docker image to deploy, for applications deployed from an image.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"is_restart": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Release the application again without rebuilding it. Defaults to false.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values that trigger a new deployment when changed.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"success_statuses": schema.SetAttribute{
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+				Default:             setdefault.StaticValue(stringSetValue(defaultDeploymentSuccessStatuses)),
+				MarkdownDescription: "Deployment statuses that end the wait successfully. Defaults to `successful` and `success`.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(knownDeploymentStatuses...)),
+				},
+			},
+			"failure_statuses": schema.SetAttribute{
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+				Default:             setdefault.StaticValue(stringSetValue(defaultDeploymentFailureStatuses)),
+				MarkdownDescription: "Deployment statuses that end the wait with an error. Defaults to `failed`, `canceled` and `cancelled`.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(knownDeploymentStatuses...)),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The final status of the deployment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"commit_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The hash of the commit that was deployed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"commit_message": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The message of the commit that was deployed. Null if the API did not report one.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp when the deployment was created.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *DeploymentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data DeploymentResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.SuccessStatuses.IsUnknown() || data.FailureStatuses.IsUnknown() {
+		return
+	}
+
+	statuses := expandDeploymentTerminalStatuses(ctx, data, &resp.Diagnostics)
+	for _, status := range statuses.Failure {
+		if slices.Contains(statuses.Success, status) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("failure_statuses"),
+				"Conflicting Deployment Statuses",
+				fmt.Sprintf("Status %q cannot be both a success and a failure status.", status),
+			)
+		}
+	}
+}
+
+func (r *DeploymentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(SevallaProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected SevallaProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *DeploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DeploymentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Parse the settings the wait needs first, so a new deployment is never left out of state
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultDeploymentCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	statuses := expandDeploymentTerminalStatuses(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	triggerReq := sevallaapi.TriggerDeploymentRequest{
+		AppID:       data.AppID.ValueString(),
+		Branch:      data.Branch.ValueString(),
+		DockerImage: data.DockerImage.ValueString(),
+		IsRestart:   data.IsRestart.ValueBool(),
+	}
+
+	tflog.Debug(ctx, "Triggering deployment", map[string]interface{}{
+		"app_id":     triggerReq.AppID,
+		"branch":     triggerReq.Branch,
+		"is_restart": triggerReq.IsRestart,
+	})
+
+	triggered, err := r.client.Deployments.Trigger(ctx, triggerReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to trigger deployment, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(triggered.Deployment.ID)
+
+	deployment, err := r.waitForDeployment(ctx, data.ID.ValueString(), statuses, createTimeout)
+	if deployment != nil {
+		mapApplicationDeploymentToModel(&data, deployment)
+	} else {
+		data.Status = types.StringNull()
+		data.CommitHash = types.StringNull()
+		data.CommitMessage = types.StringNull()
+		data.CreatedAt = types.Int64Null()
+	}
+
+	// Save the deployment even if it failed so it is tainted and redeployed on the next apply
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if errors.Is(err, errDeploymentFailed) {
+		resp.Diagnostics.AddError(
+			"Deployment Failed",
+			fmt.Sprintf("Deployment %s of application %s finished with status %q.",
+				data.ID.ValueString(), data.AppID.ValueString(), deployment.Status),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for deployment, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "Created deployment resource")
+}
+
+func (r *DeploymentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DeploymentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app, err := r.client.Applications.Get(ctx, data.AppID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read deployment, got error: %s", err))
+		return
+	}
+
+	// Older deployments drop off the application's history; keep the recorded values for those
+	deployment := findAppDeployment(app.App.Deployments, data.ID.ValueString())
+	if deployment != nil {
+		mapAppDeploymentToModel(&data, deployment)
+	} else if data.Status.IsNull() {
+		// Nothing was recorded, as after an import of an unknown deployment
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DeploymentResourceModel
+
+	// Every other argument forces replacement, so only the wait settings can change in place
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DeploymentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deployments are historical records and cannot be deleted
+	tflog.Debug(ctx, "Removing deployment from state", map[string]interface{}{
+		"deployment_id": data.ID.ValueString(),
+	})
+}
+
+func (r *DeploymentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	appID, deploymentID, ok := strings.Cut(req.ID, "/")
+	if !ok || appID == "" || deploymentID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: app_id/deployment_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), deploymentID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), appID)...)
+	// Set the defaults so an unchanged configuration does not plan a new deployment
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("is_restart"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("success_statuses"), stringSetValue(defaultDeploymentSuccessStatuses))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("failure_statuses"), stringSetValue(defaultDeploymentFailureStatuses))...)
+}
+
+// waitForDeployment polls the deployment until it reaches one of the terminal statuses.
+// It returns errDeploymentFailed together with the deployment if it reached a failure status.
+func (r *DeploymentResource) waitForDeployment(
+	ctx context.Context,
+	deploymentID string,
+	statuses deploymentTerminalStatuses,
+	timeout time.Duration,
+) (*sevallaapi.ApplicationDeployment, error) {
+	ctx, cancel := r.client.WithDeadline(ctx)
+	defer cancel()
+
+	ticker := time.NewTicker(deploymentPollInterval)
+	defer ticker.Stop()
+	deadline := time.After(timeout)
+
+	for {
+		select {
+		case <-ticker.C:
+			resp, err := r.client.Deployments.GetByID(ctx, deploymentID)
+			var apiErr *sevallaapi.APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// A just triggered deployment may not be readable yet
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get deployment status: %w", err)
+			}
+			deployment := &resp.Deployment
+
+			tflog.Debug(ctx, "Polled deployment status", map[string]interface{}{
+				"deployment_id": deploymentID,
+				"status":        deployment.Status,
+			})
+
+			switch {
+			case slices.Contains(statuses.Success, deployment.Status):
+				return deployment, nil
+			case slices.Contains(statuses.Failure, deployment.Status):
+				return deployment, errDeploymentFailed
+			}
+		case <-deadline:
+			return nil, fmt.Errorf("deployment did not finish after %s", timeout)
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+	}
+}
+
+// expandDeploymentTerminalStatuses reads the configured terminal statuses, falling back to the defaults when unset.
+func expandDeploymentTerminalStatuses(ctx context.Context, data DeploymentResourceModel, diags *diag.Diagnostics) deploymentTerminalStatuses {
+	statuses := deploymentTerminalStatuses{
+		Success: defaultDeploymentSuccessStatuses,
+		Failure: defaultDeploymentFailureStatuses,
+	}
+
+	if !data.SuccessStatuses.IsNull() && !data.SuccessStatuses.IsUnknown() {
+		statuses.Success = nil
+		diags.Append(data.SuccessStatuses.ElementsAs(ctx, &statuses.Success, false)...)
+	}
+	if !data.FailureStatuses.IsNull() && !data.FailureStatuses.IsUnknown() {
+		statuses.Failure = nil
+		diags.Append(data.FailureStatuses.ElementsAs(ctx, &statuses.Failure, false)...)
+	}
+
+	return statuses
+}
+
+func stringSetValue(values []string) types.Set {
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+func findAppDeployment(deployments []sevallaapi.AppDeployment, id string) *sevallaapi.AppDeployment {
+	for i := range deployments {
+		if deployments[i].ID == id {
+			return &deployments[i]
+		}
+	}
+	return nil
+}
+
+// mapApplicationDeploymentToModel maps a deployment read by ID, which names the commit hash commit_sha.
+func mapApplicationDeploymentToModel(data *DeploymentResourceModel, deployment *sevallaapi.ApplicationDeployment) {
+	commitHash := ""
+	if deployment.CommitSHA != nil {
+		commitHash = *deployment.CommitSHA
+	}
+	data.Status = types.StringValue(deployment.Status)
+	data.CommitHash = types.StringValue(commitHash)
+	data.CommitMessage = types.StringPointerValue(deployment.CommitMessage)
+	data.CreatedAt = types.Int64Value(deployment.CreatedAt)
+}
+
+func mapAppDeploymentToModel(data *DeploymentResourceModel, deployment *sevallaapi.AppDeployment) {
+	data.Status = types.StringValue(deployment.Status)
+	data.CommitHash = types.StringValue(deployment.CommitHash)
+	data.CommitMessage = types.StringPointerValue(deployment.CommitMessage)
+	data.CreatedAt = types.Int64Value(deployment.CreatedAt)
+}
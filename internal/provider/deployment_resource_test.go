@@ -0,0 +1,405 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestAccDeploymentResource(t *testing.T) {
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []tfresource.TestStep{
+			{
+				Config: testAccDeploymentResourceConfig("test-deployment-app"),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttrSet("sevalla_deployment.test", "id"),
+					tfresource.TestCheckResourceAttrSet("sevalla_deployment.test", "status"),
+					tfresource.TestCheckResourceAttrSet("sevalla_deployment.test", "commit_hash"),
+					tfresource.TestCheckResourceAttrSet("sevalla_deployment.test", "created_at"),
+				),
+			},
+			{
+				ResourceName:      "sevalla_deployment.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccDeploymentImportID("sevalla_deployment.test"),
+				ImportStateVerify: true,
+				// The API does not record the branch a deployment was triggered with
+				ImportStateVerifyIgnore: []string{"branch"},
+			},
+		},
+	})
+}
+
+// testAccDeploymentImportID returns the app_id/deployment_id import identifier of a deployment.
+func testAccDeploymentImportID(resourceName string) tfresource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		return rs.Primary.Attributes["app_id"] + "/" + rs.Primary.ID, nil
+	}
+}
+
+func testAccDeploymentResourceConfig(name string) string {
+	return testAccApplicationResourceConfig(name) + `
+resource "sevalla_deployment" "test" {
+  app_id = sevalla_application.test.id
+  branch = "main"
+}
+`
+}
+
+// testDeploymentServer serves the application fixture, reporting the given deployment
+// statuses in sequence and replacing its commit message with commitMessage.
+func testDeploymentServer(t *testing.T, commitMessage string, statuses ...string) (*DeploymentResource, *sevallaapitest.Server) {
+	t.Helper()
+
+	deploymentPollInterval = time.Millisecond
+	t.Cleanup(func() { deploymentPollInterval = 10 * time.Second })
+
+	server := sevallaapitest.NewServer(t)
+	var calls int
+	server.Handle(http.MethodGet, "/applications/deployments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		body := strings.Replace(sevallaapitest.ApplicationDeploymentFixture, `"status": "success"`, fmt.Sprintf(`"status": %q`, status), 1)
+		body = strings.Replace(body, `"commit_message": "Initial commit"`, `"commit_message": `+commitMessage, 1)
+		sevallaapitest.JSONResponse(http.StatusOK, body)(w, r)
+	})
+
+	r := &DeploymentResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+	return r, server
+}
+
+func testDeploymentCreate(t *testing.T, r *DeploymentResource) (DeploymentResourceModel, *resource.CreateResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := testNullValues(objectType)
+	values["app_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.ApplicationID)
+	values["is_restart"] = tftypes.NewValue(tftypes.Bool, false)
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Create(ctx, req, resp)
+
+	var data DeploymentResourceModel
+	if !resp.State.Raw.IsNull() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	}
+	return data, resp
+}
+
+func TestDeploymentResourceCreate(t *testing.T) {
+	t.Run("commit details", func(t *testing.T) {
+		r, server := testDeploymentServer(t, `"Initial commit"`, "inProgress", "success")
+
+		data, resp := testDeploymentCreate(t, r)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if data.ID.ValueString() != sevallaapitest.DeploymentID {
+			t.Errorf("expected id %q, got %s", sevallaapitest.DeploymentID, data.ID)
+		}
+		if data.Status.ValueString() != "success" {
+			t.Errorf("expected success status, got %s", data.Status)
+		}
+		if data.CommitHash.ValueString() != "a1b2c3d" {
+			t.Errorf("expected commit hash a1b2c3d, got %s", data.CommitHash)
+		}
+		if data.CommitMessage.ValueString() != "Initial commit" {
+			t.Errorf("expected commit message, got %s", data.CommitMessage)
+		}
+
+		trigger := server.Requests()[0]
+		if trigger.Method != http.MethodPost || trigger.Path != "/applications/deployments" {
+			t.Fatalf("expected the deployment to be triggered first, got %s %s", trigger.Method, trigger.Path)
+		}
+		if !strings.Contains(string(trigger.Body), `"app_id":"app-1"`) || strings.Contains(string(trigger.Body), "is_restart") {
+			t.Errorf("unexpected trigger body %s", trigger.Body)
+		}
+		for _, poll := range server.Requests()[1:] {
+			if poll.Method != http.MethodGet || poll.Path != "/applications/deployments/"+sevallaapitest.DeploymentID {
+				t.Errorf("expected the deployment to be polled by ID, got %s %s", poll.Method, poll.Path)
+			}
+		}
+	})
+
+	t.Run("not readable yet", func(t *testing.T) {
+		r, server := testDeploymentServer(t, `"Initial commit"`, "success")
+		var polls int
+		server.Handle(http.MethodGet, "/applications/deployments/{id}", func(w http.ResponseWriter, r *http.Request) {
+			polls++
+			if polls == 1 {
+				sevallaapitest.JSONResponse(http.StatusNotFound, `{"message":"Deployment not found","status":404}`)(w, r)
+				return
+			}
+			sevallaapitest.JSONResponse(http.StatusOK, sevallaapitest.ApplicationDeploymentFixture)(w, r)
+		})
+
+		data, resp := testDeploymentCreate(t, r)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if data.Status.ValueString() != "success" || polls != 2 {
+			t.Errorf("expected the wait to retry past the 404, got status %s after %d polls", data.Status, polls)
+		}
+	})
+
+	t.Run("invalid timeouts", func(t *testing.T) {
+		ctx := context.Background()
+		r, server := testDeploymentServer(t, `"Initial commit"`, "success")
+
+		var schemaResp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+		values := testNullValues(objectType)
+		values["app_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.ApplicationID)
+		values["is_restart"] = tftypes.NewValue(tftypes.Bool, false)
+		timeoutsType, ok := objectType.AttributeTypes["timeouts"].(tftypes.Object)
+		if !ok {
+			t.Fatalf("expected timeouts to be an object, got %T", objectType.AttributeTypes["timeouts"])
+		}
+		timeouts := testNullValues(timeoutsType)
+		timeouts["create"] = tftypes.NewValue(tftypes.String, "soon")
+		values["timeouts"] = tftypes.NewValue(timeoutsType, timeouts)
+
+		resp := &resource.CreateResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		r.Create(ctx, resource.CreateRequest{
+			Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected an invalid timeout error")
+		}
+		if got := resp.Diagnostics.Errors()[0].Summary(); !strings.Contains(got, "Timeout") {
+			t.Errorf("expected a timeout parse error, got %q", got)
+		}
+		if got := len(server.Requests()); got != 0 {
+			t.Errorf("expected no deployment to be triggered, got %d requests", got)
+		}
+	})
+
+	t.Run("null commit message", func(t *testing.T) {
+		r, _ := testDeploymentServer(t, "null", "successful")
+
+		data, resp := testDeploymentCreate(t, r)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if !data.CommitMessage.IsNull() {
+			t.Errorf("expected a null commit message, got %s", data.CommitMessage)
+		}
+		if data.CommitHash.ValueString() != "a1b2c3d" {
+			t.Errorf("expected commit hash a1b2c3d, got %s", data.CommitHash)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		r, _ := testDeploymentServer(t, `"Initial commit"`, "inProgress", "failed")
+
+		data, resp := testDeploymentCreate(t, r)
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected an error diagnostic")
+		}
+		if data.Status.ValueString() != "failed" || data.CommitHash.ValueString() != "a1b2c3d" {
+			t.Errorf("expected the failed deployment to be saved, got %+v", data)
+		}
+	})
+}
+
+var testDefaultDeploymentStatuses = deploymentTerminalStatuses{
+	Success: defaultDeploymentSuccessStatuses,
+	Failure: defaultDeploymentFailureStatuses,
+}
+
+func TestWaitForDeployment(t *testing.T) {
+	t.Run("cancelled", func(t *testing.T) {
+		r, _ := testDeploymentServer(t, `"Initial commit"`, "waiting", "cancelled")
+
+		deployment, err := r.waitForDeployment(context.Background(), sevallaapitest.DeploymentID, testDefaultDeploymentStatuses, time.Second)
+		if !errors.Is(err, errDeploymentFailed) {
+			t.Fatalf("expected errDeploymentFailed, got %v", err)
+		}
+		if deployment == nil || deployment.Status != "cancelled" {
+			t.Errorf("expected the cancelled deployment, got %+v", deployment)
+		}
+	})
+
+	t.Run("custom statuses", func(t *testing.T) {
+		r, server := testDeploymentServer(t, `"Initial commit"`, "pending", "canceled", "successful")
+		statuses := deploymentTerminalStatuses{
+			Success: []string{"successful"},
+			Failure: []string{"failed"},
+		}
+
+		deployment, err := r.waitForDeployment(context.Background(), sevallaapitest.DeploymentID, statuses, time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if deployment.Status != "successful" {
+			t.Errorf("expected the wait to continue past canceled, got %q", deployment.Status)
+		}
+		if got := len(server.Requests()); got != 3 {
+			t.Errorf("expected 3 polls, got %d", got)
+		}
+	})
+
+	t.Run("operation deadline", func(t *testing.T) {
+		r, _ := testDeploymentServer(t, `"Initial commit"`, "inProgress")
+		r.client.Deadline = time.Now().Add(20 * time.Millisecond)
+
+		_, err := r.waitForDeployment(context.Background(), sevallaapitest.DeploymentID, testDefaultDeploymentStatuses, time.Minute)
+		if !errors.Is(err, sevallaapi.ErrOperationDeadlineExceeded) {
+			t.Fatalf("expected ErrOperationDeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		r, _ := testDeploymentServer(t, `"Initial commit"`, "inProgress")
+
+		_, err := r.waitForDeployment(context.Background(), sevallaapitest.DeploymentID, testDefaultDeploymentStatuses, 20*time.Millisecond)
+		if err == nil || errors.Is(err, errDeploymentFailed) {
+			t.Fatalf("expected a timeout error, got %v", err)
+		}
+	})
+}
+
+func TestDeploymentResourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := &DeploymentResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	setType := tftypes.Set{ElementType: tftypes.String}
+
+	stringSet := func(values ...string) tftypes.Value {
+		elements := make([]tftypes.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, tftypes.NewValue(tftypes.String, value))
+		}
+		return tftypes.NewValue(setType, elements)
+	}
+
+	tests := map[string]struct {
+		success, failure tftypes.Value
+		wantErrors       int
+	}{
+		"defaults":        {tftypes.NewValue(setType, nil), tftypes.NewValue(setType, nil), 0},
+		"custom":          {stringSet("successful"), stringSet("failed"), 0},
+		"overlap":         {stringSet("successful", "failed"), stringSet("failed"), 1},
+		"default overlap": {tftypes.NewValue(setType, nil), stringSet("success"), 1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := testNullValues(objectType)
+			values["app_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.ApplicationID)
+			values["success_statuses"] = tt.success
+			values["failure_statuses"] = tt.failure
+
+			req := resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+			}
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, req, resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != tt.wantErrors {
+				t.Errorf("expected %d errors, got %d: %v", tt.wantErrors, got, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestDeploymentResourceImportState(t *testing.T) {
+	ctx := context.Background()
+	r, _ := testDeploymentServer(t, `"Initial commit"`, "successful")
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	importAndRead := func(t *testing.T, id string) (*resource.ReadResponse, *resource.ImportStateResponse) {
+		t.Helper()
+
+		importResp := &resource.ImportStateResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, importResp)
+		if importResp.Diagnostics.HasError() {
+			return nil, importResp
+		}
+
+		readResp := &resource.ReadResponse{State: importResp.State}
+		r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+		return readResp, importResp
+	}
+
+	t.Run("app and deployment", func(t *testing.T) {
+		readResp, importResp := importAndRead(t, sevallaapitest.ApplicationID+"/"+sevallaapitest.DeploymentID)
+		if importResp.Diagnostics.HasError() || readResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v %v", importResp.Diagnostics, readResp.Diagnostics)
+		}
+
+		var data DeploymentResourceModel
+		readResp.Diagnostics.Append(readResp.State.Get(ctx, &data)...)
+		if readResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+		}
+		if data.AppID.ValueString() != sevallaapitest.ApplicationID || data.ID.ValueString() != sevallaapitest.DeploymentID {
+			t.Errorf("expected app and deployment IDs to be set, got %s and %s", data.AppID, data.ID)
+		}
+		if data.CommitHash.ValueString() != "a1b2c3d" {
+			t.Errorf("expected commit hash a1b2c3d, got %s", data.CommitHash)
+		}
+		if data.IsRestart.IsNull() || !data.SuccessStatuses.Equal(stringSetValue(defaultDeploymentSuccessStatuses)) {
+			t.Errorf("expected the default wait settings, got %s and %s", data.IsRestart, data.SuccessStatuses)
+		}
+	})
+
+	t.Run("unknown deployment", func(t *testing.T) {
+		readResp, importResp := importAndRead(t, sevallaapitest.ApplicationID+"/missing")
+		if importResp.Diagnostics.HasError() || readResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v %v", importResp.Diagnostics, readResp.Diagnostics)
+		}
+		if !readResp.State.Raw.IsNull() {
+			t.Error("expected an unknown deployment to be removed from state")
+		}
+	})
+
+	for _, id := range []string{sevallaapitest.DeploymentID, "/" + sevallaapitest.DeploymentID, sevallaapitest.ApplicationID + "/"} {
+		t.Run("invalid "+id, func(t *testing.T) {
+			_, importResp := importAndRead(t, id)
+			if !importResp.Diagnostics.HasError() {
+				t.Fatal("expected an invalid import identifier error")
+			}
+		})
+	}
+}
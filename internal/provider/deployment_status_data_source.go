@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DeploymentStatusDataSource{}
+
+func NewDeploymentStatusDataSource() datasource.DataSource {
+	return &DeploymentStatusDataSource{}
+}
+
+// DeploymentStatusDataSource defines the data source implementation.
+type DeploymentStatusDataSource struct {
+	client *sevallaapi.Client
+}
+
+// DeploymentStatusDataSourceModel describes the data source data model.
+type DeploymentStatusDataSourceModel struct {
+	AppID        types.String `tfsdk:"app_id"`
+	DeploymentID types.String `tfsdk:"deployment_id"`
+	Status       types.String `tfsdk:"status"`
+	Progress     types.Int64  `tfsdk:"progress"`
+}
+
+func (d *DeploymentStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment_status"
+}
+
+func (d *DeploymentStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches only the status of an application deployment, for gating on it in CI. " +
+			"It makes a single small request and does not load the application or its build logs, unlike " +
+			"`sevalla_deployment`. The status is read live every time the data source is read, including " +
+			"during `terraform refresh`, and is never cached by the provider.",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the application the deployment belongs to.",
+			},
+			"deployment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the deployment.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The current status of the deployment.",
+			},
+			"progress": schema.Int64Attribute{
+				Computed: true,
+				MarkdownDescription: "The completion percentage of the deployment. The API does not report partial progress, " +
+					"so this is `0` until the deployment finishes and `100` once it has succeeded, failed or been cancelled.",
+			},
+		},
+	}
+}
+
+func (d *DeploymentStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(SevallaProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected SevallaProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *DeploymentStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DeploymentStatusDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deployment, err := d.client.Deployments.GetByID(ctx, data.DeploymentID.ValueString())
+	var apiErr *sevallaapi.APIError
+	if (errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) ||
+		(err == nil && deployment.Deployment.AppID != data.AppID.ValueString()) {
+		resp.Diagnostics.AddError(
+			"Deployment Not Found",
+			fmt.Sprintf("Deployment %s of application %s does not exist.",
+				data.DeploymentID.ValueString(), data.AppID.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read deployment status, got error: %s", err))
+		return
+	}
+
+	data.Status = types.StringValue(deployment.Deployment.Status)
+	data.Progress = types.Int64Value(deploymentProgress(deployment.Deployment.Status))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// deploymentProgress maps a deployment status to a completion percentage, as the API reports none.
+func deploymentProgress(status string) int64 {
+	if slices.Contains(defaultDeploymentSuccessStatuses, status) || slices.Contains(defaultDeploymentFailureStatuses, status) {
+		return 100
+	}
+	return 0
+}
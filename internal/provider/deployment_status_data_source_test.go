@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestDeploymentStatusDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	d := &DeploymentStatusDataSource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	read := func(appID, deploymentID string) (DeploymentStatusDataSourceModel, *datasource.ReadResponse) {
+		values := testNullValues(objectType)
+		values["app_id"] = tftypes.NewValue(tftypes.String, appID)
+		values["deployment_id"] = tftypes.NewValue(tftypes.String, deploymentID)
+
+		req := datasource.ReadRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}
+		resp := &datasource.ReadResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		d.Read(ctx, req, resp)
+
+		var data DeploymentStatusDataSourceModel
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		}
+		return data, resp
+	}
+
+	t.Run("finished", func(t *testing.T) {
+		data, resp := read(sevallaapitest.ApplicationID, sevallaapitest.DeploymentID)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if data.Status.ValueString() != "success" || data.Progress.ValueInt64() != 100 {
+			t.Errorf("unexpected status %s and progress %s", data.Status, data.Progress)
+		}
+
+		req, ok := server.LastRequest()
+		if !ok || req.Path != "/applications/deployments/"+sevallaapitest.DeploymentID {
+			t.Errorf("expected only the deployment to be fetched, got %+v", req)
+		}
+	})
+
+	t.Run("in progress", func(t *testing.T) {
+		server.HandleJSON(http.MethodGet, "/applications/deployments/{id}", http.StatusOK,
+			`{"deployment": {"id": "dep-2", "app_id": "app-1", "status": "building"}}`)
+
+		data, resp := read(sevallaapitest.ApplicationID, "dep-2")
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if data.Status.ValueString() != "building" || data.Progress.ValueInt64() != 0 {
+			t.Errorf("unexpected status %s and progress %s", data.Status, data.Progress)
+		}
+	})
+
+	t.Run("other application", func(t *testing.T) {
+		_, resp := read("app-2", "dep-2")
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Deployment Not Found" {
+			t.Errorf("expected a deployment not found error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		server.HandleJSON(http.MethodGet, "/applications/deployments/{id}", http.StatusNotFound,
+			`{"message":"Deployment not found","status":404}`)
+
+		_, resp := read(sevallaapitest.ApplicationID, "missing")
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Deployment Not Found" {
+			t.Errorf("expected a deployment not found error, got %v", resp.Diagnostics)
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package provider
+
+import "github.com/hashicorp/terraform-plugin-go/tftypes"
+
+// testNullValues returns a value for every attribute of objectType, all null, for tests to set
+// the attributes they need before building the object.
+func testNullValues(objectType tftypes.Object) map[string]tftypes.Value {
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	return values
+}
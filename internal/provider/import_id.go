@@ -0,0 +1,36 @@
+package provider
+
+import "strings"
+
+// companyImportIDPrefix starts import identifiers of the form company/<company_id>/<kind>/<id>,
+// which let tools that generate import blocks address every resource the same way.
+const companyImportIDPrefix = "company/"
+
+// parseCompanyImportID splits an import identifier of the form company/<company_id>/<kind>/<id>,
+// where kind names the resource being imported, such as "application". It returns false if id
+// does not have that format or names a different kind of resource.
+func parseCompanyImportID(id, kind string) (companyID, resourceID string, ok bool) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 4 || parts[0]+"/" != companyImportIDPrefix || parts[1] == "" || parts[2] != kind || parts[3] == "" {
+		return "", "", false
+	}
+	return parts[1], parts[3], true
+}
+
+// parseCompanyScopedImportID splits an import identifier of a company-owned resource, which is
+// either <id>, <company_id>/<id> or company/<company_id>/<kind>/<id>. The company ID is empty
+// for a bare <id>. It returns false if id has none of these formats.
+func parseCompanyScopedImportID(id, kind string) (companyID, resourceID string, ok bool) {
+	if strings.HasPrefix(id, companyImportIDPrefix) {
+		return parseCompanyImportID(id, kind)
+	}
+
+	companyID, resourceID, scoped := strings.Cut(id, "/")
+	if !scoped {
+		return "", id, id != ""
+	}
+	if companyID == "" || resourceID == "" || strings.Contains(resourceID, "/") {
+		return "", "", false
+	}
+	return companyID, resourceID, true
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// maskSecrets returns a context that redacts the given values from the messages and fields of
+// all log entries written with it, including entries logged by helpers it is passed to.
+// Only string field values are masked, so secrets must not be logged inside slices or maps.
+func maskSecrets(ctx context.Context, secrets ...string) context.Context {
+	nonEmpty := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		if secret != "" {
+			nonEmpty = append(nonEmpty, secret)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return ctx
+	}
+
+	ctx = tflog.MaskMessageStrings(ctx, nonEmpty...)
+	return tflog.MaskAllFieldValuesStrings(ctx, nonEmpty...)
+}
+
+// maskEnvVarValues redacts the values of the given environment variables from logs.
+func maskEnvVarValues(ctx context.Context, envVars []sevallaapi.EnvVar) context.Context {
+	values := make([]string, len(envVars))
+	for i, envVar := range envVars {
+		values[i] = envVar.Value
+	}
+	return maskSecrets(ctx, values...)
+}
+
+// envVarKeys returns the keys of the given environment variables, which are safe to log.
+func envVarKeys(envVars []sevallaapi.EnvVar) []string {
+	keys := make([]string, len(envVars))
+	for i, envVar := range envVars {
+		keys[i] = envVar.Key
+	}
+	return keys
+}
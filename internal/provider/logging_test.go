@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+const testSecret = "s3cr3t-api-key"
+
+func TestMaskSecrets(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	ctx = maskSecrets(ctx, "", testSecret)
+	tflog.Debug(ctx, "Using "+testSecret, map[string]interface{}{
+		"value": testSecret,
+		"other": "public",
+	})
+
+	if strings.Contains(output.String(), testSecret) {
+		t.Errorf("expected the secret to be masked, got %s", output.String())
+	}
+	if !strings.Contains(output.String(), "public") {
+		t.Errorf("expected other values to be logged, got %s", output.String())
+	}
+}
+
+func TestApplicationResourceUpdate_DoesNotLogSecrets(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	server := sevallaapitest.NewServer(t)
+	r := &ApplicationResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	envVarsType, ok := objectType.AttributeTypes["environment_variables"].(tftypes.List)
+	if !ok {
+		t.Fatalf("unexpected environment_variables type %s", objectType.AttributeTypes["environment_variables"])
+	}
+	envVarType, ok := envVarsType.ElementType.(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected environment variable type %s", envVarsType.ElementType)
+	}
+
+	values := testNullValues(objectType)
+	values["id"] = tftypes.NewValue(tftypes.String, sevallaapitest.ApplicationID)
+	values["display_name"] = tftypes.NewValue(tftypes.String, "my-app")
+	values["deployments_limit"] = tftypes.NewValue(tftypes.Number, 0)
+	values["environment_variables"] = tftypes.NewValue(envVarsType, []tftypes.Value{
+		tftypes.NewValue(envVarType, map[string]tftypes.Value{
+			"key":                    tftypes.NewValue(tftypes.String, "API_KEY"),
+			"value":                  tftypes.NewValue(tftypes.String, testSecret),
+			"from_database":          tftypes.NewValue(tftypes.String, nil),
+			"from_database_property": tftypes.NewValue(tftypes.String, nil),
+		}),
+	})
+	plan := tftypes.NewValue(objectType, values)
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: plan},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Update(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if !strings.Contains(string(server.Requests()[0].Body), testSecret) {
+		t.Fatal("expected the secret to be sent to the API")
+	}
+	if !strings.Contains(output.String(), "API_KEY") {
+		t.Errorf("expected the environment variable key to be logged, got %s", output.String())
+	}
+	if strings.Contains(output.String(), testSecret) {
+		t.Errorf("expected the environment variable value not to be logged, got %s", output.String())
+	}
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+var metricsDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// metricsIntervals are the intervals accepted by metrics data sources, shortest first.
+var metricsIntervals = []string{"hour", "day", "week", "month"}
+
+// metricsQueryAttributes returns the start_date, end_date and interval arguments shared by the
+// metrics data sources, together with their timeframe and data results.
+func metricsQueryAttributes() map[string]schema.Attribute {
+	dateValidators := []validator.String{
+		stringvalidator.RegexMatches(metricsDatePattern, "must be a date in YYYY-MM-DD format"),
+	}
+
+	return map[string]schema.Attribute{
+		"start_date": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The first day of the timeframe, in `YYYY-MM-DD` format.",
+			Validators:          dateValidators,
+		},
+		"end_date": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The last day of the timeframe, in `YYYY-MM-DD` format. The whole day is included.",
+			Validators:          dateValidators,
+		},
+		"interval": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The length of each data point: `hour`, `day`, `week` or `month`.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(metricsIntervals...),
+			},
+		},
+		"timeframe": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "The start time of each data point, in the same order as `data`.",
+		},
+		"data": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.Float64Type,
+			MarkdownDescription: "The value of each data point. Empty when there is no data for the timeframe.",
+		},
+	}
+}
+
+// newMetricsQuery builds the API query from a metrics data source's arguments.
+func newMetricsQuery(startDate, endDate, interval types.String) sevallaapi.MetricsQuery {
+	return sevallaapi.MetricsQuery{
+		StartDate: startDate.ValueString(),
+		EndDate:   endDate.ValueString(),
+		Interval:  interval.ValueString(),
+	}
+}
+
+// flattenMetricsSeries converts a metrics series to its timeframe and data list values. An empty
+// series results in empty lists rather than null ones.
+func flattenMetricsSeries(ctx context.Context, timeframe []string, data []float64, diags *diag.Diagnostics) (types.List, types.List) {
+	if timeframe == nil {
+		timeframe = []string{}
+	}
+	if data == nil {
+		data = []float64{}
+	}
+
+	timeframeValue, d := types.ListValueFrom(ctx, types.StringType, timeframe)
+	diags.Append(d...)
+	dataValue, d := types.ListValueFrom(ctx, types.Float64Type, data)
+	diags.Append(d...)
+	return timeframeValue, dataValue
+}
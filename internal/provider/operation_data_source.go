@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OperationDataSource{}
+
+func NewOperationDataSource() datasource.DataSource {
+	return &OperationDataSource{}
+}
+
+// OperationDataSource defines the data source implementation.
+type OperationDataSource struct {
+	client *sevallaapi.Client
+}
+
+// OperationDataSourceModel describes the data source data model.
+type OperationDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Status      types.String `tfsdk:"status"`
+	Type        types.String `tfsdk:"type"`
+	ResourceID  types.String `tfsdk:"resource_id"`
+	Progress    types.Int64  `tfsdk:"progress"`
+	Message     types.String `tfsdk:"message"`
+	CreatedAt   types.Int64  `tfsdk:"created_at"`
+	CompletedAt types.Int64  `tfsdk:"completed_at"`
+	Error       types.String `tfsdk:"error"`
+}
+
+func (d *OperationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_operation"
+}
+
+func (d *OperationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the status of an asynchronous Sevalla operation, such as the creation of a WordPress site.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the operation.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the operation: `pending`, `running`, `completed` or `failed`.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The kind of operation, for example `create_site`.",
+			},
+			"resource_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the resource the operation acts on. Null if the API did not report one.",
+			},
+			"progress": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The progress of the operation, from 0 to 100.",
+			},
+			"message": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The latest status message of the operation.",
+			},
+			"created_at": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp when the operation was started.",
+			},
+			"completed_at": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp when the operation finished. Null while it is still running.",
+			},
+			"error": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The error reported by a failed operation. Null otherwise.",
+			},
+		},
+	}
+}
+
+func (d *OperationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(SevallaProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected SevallaProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *OperationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OperationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	op, err := d.client.Operations.GetStatus(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read operation, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(op.ID)
+	data.Status = types.StringValue(op.Status)
+	data.Type = types.StringValue(op.Type)
+	data.ResourceID = stringValueOrNull(op.ResourceID)
+	data.Progress = types.Int64Value(int64(op.Progress))
+	data.Message = types.StringValue(op.Message)
+	data.CreatedAt = types.Int64Value(op.CreatedAt)
+	data.CompletedAt = types.Int64PointerValue(op.CompletedAt)
+	data.Error = types.StringPointerValue(op.Error)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func testOperationRead(t *testing.T, server *sevallaapitest.Server) OperationDataSourceModel {
+	t.Helper()
+	ctx := context.Background()
+
+	d := &OperationDataSource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := testNullValues(objectType)
+	values["id"] = tftypes.NewValue(tftypes.String, sevallaapitest.OperationID)
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	d.Read(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data OperationDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	return data
+}
+
+func TestOperationDataSourceRead(t *testing.T) {
+	t.Run("completed", func(t *testing.T) {
+		data := testOperationRead(t, sevallaapitest.NewServer(t))
+
+		if data.Status.ValueString() != "completed" || data.Type.ValueString() != "create_site" {
+			t.Errorf("expected a completed create_site operation, got %s and %s", data.Status, data.Type)
+		}
+		if data.ResourceID.ValueString() != "site-1" || data.Progress.ValueInt64() != 100 {
+			t.Errorf("expected resource site-1 at 100%%, got %s and %s", data.ResourceID, data.Progress)
+		}
+		if data.CompletedAt.ValueInt64() != 1695300690620 {
+			t.Errorf("expected completed_at to be set, got %s", data.CompletedAt)
+		}
+		if !data.Error.IsNull() {
+			t.Errorf("expected no error, got %s", data.Error)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		server.HandleJSON(http.MethodGet, "/operations/{id}", http.StatusOK,
+			`{"id":"op-1","status":"failed","type":"create_site","progress":40,"message":"Provisioning","created_at":1695300630620,"error":"quota exceeded"}`)
+
+		data := testOperationRead(t, server)
+
+		if data.Error.ValueString() != "quota exceeded" {
+			t.Errorf("expected the operation error, got %s", data.Error)
+		}
+		if !data.CompletedAt.IsNull() || !data.ResourceID.IsNull() {
+			t.Errorf("expected completed_at and resource_id to be null, got %s and %s", data.CompletedAt, data.ResourceID)
+		}
+	})
+}
@@ -22,7 +22,8 @@ type PerformanceConfig struct {
 	BatchSize    int
 	BatchTimeout time.Duration
 
-	// Connection pooling configuration
+	// Connection pooling configuration, applied to the client's transport. net/http has no
+	// limit on a connection's total lifetime, so ConnMaxLifetime is validated but not applied.
 	MaxIdleConns    int
 	MaxOpenConns    int
 	ConnMaxLifetime time.Duration
@@ -32,6 +33,9 @@ type PerformanceConfig struct {
 	RequestTimeout time.Duration
 	RetryAttempts  int
 	RetryDelay     time.Duration
+
+	// Response compression configuration
+	CompressionEnabled bool
 }
 
 // DefaultPerformanceConfig returns default performance configuration.
@@ -61,6 +65,9 @@ func DefaultPerformanceConfig() *PerformanceConfig {
 		RequestTimeout: 30 * time.Second,
 		RetryAttempts:  3,
 		RetryDelay:     1 * time.Second,
+
+		// Response compression defaults
+		CompressionEnabled: true,
 	}
 }
 
@@ -180,6 +187,12 @@ func loadRequestConfig(config *PerformanceConfig) {
 			config.RetryDelay = delay
 		}
 	}
+
+	if val := os.Getenv("SEVALLA_COMPRESSION_ENABLED"); val != "" {
+		if enabled, err := strconv.ParseBool(val); err == nil {
+			config.CompressionEnabled = enabled
+		}
+	}
 }
 
 // Validate validates the performance configuration.
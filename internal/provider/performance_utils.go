@@ -2,7 +2,11 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -67,6 +71,18 @@ func (pc *ProviderCache) Clear() {
 	pc.cache = make(map[string]*CacheEntry)
 }
 
+// DeletePrefix removes all items whose key starts with prefix.
+func (pc *ProviderCache) DeletePrefix(prefix string) {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+
+	for key := range pc.cache {
+		if strings.HasPrefix(key, prefix) {
+			delete(pc.cache, key)
+		}
+	}
+}
+
 // ClearExpired removes all expired entries from the cache.
 func (pc *ProviderCache) ClearExpired() {
 	pc.mutex.Lock()
@@ -79,6 +95,14 @@ func (pc *ProviderCache) ClearExpired() {
 	}
 }
 
+// Operations supported by the BatchProcessor. Their Parameters is the ID of the resource to get.
+const (
+	batchGetApplication = "get_application"
+	batchGetDatabase    = "get_database"
+	batchGetStaticSite  = "get_static_site"
+	batchGetPipeline    = "get_pipeline"
+)
+
 // BatchOperation represents a batch operation for API calls.
 type BatchOperation struct {
 	ID         string
@@ -87,24 +111,32 @@ type BatchOperation struct {
 	Result     interface{}
 	Error      error
 	Done       chan bool
+
+	// Context is used for the API call made for the operation. Defaults to context.Background.
+	Context context.Context
 }
 
 // BatchProcessor handles batch operations to reduce API calls.
 type BatchProcessor struct {
+	client     *sevallaapi.Client
 	operations chan *BatchOperation
 	results    map[string]*BatchOperation
 	mutex      sync.RWMutex
 	batchSize  int
 	batchTime  time.Duration
+	done       chan struct{}
+	stopOnce   sync.Once
 }
 
-// NewBatchProcessor creates a new batch processor.
-func NewBatchProcessor(batchSize int, batchTime time.Duration) *BatchProcessor {
+// NewBatchProcessor creates a new batch processor that executes operations with client.
+func NewBatchProcessor(client *sevallaapi.Client, batchSize int, batchTime time.Duration) *BatchProcessor {
 	bp := &BatchProcessor{
+		client:     client,
 		operations: make(chan *BatchOperation, batchSize*2),
 		results:    make(map[string]*BatchOperation),
 		batchSize:  batchSize,
 		batchTime:  batchTime,
+		done:       make(chan struct{}),
 	}
 
 	// Start the batch processor
@@ -113,17 +145,37 @@ func NewBatchProcessor(batchSize int, batchTime time.Duration) *BatchProcessor {
 	return bp
 }
 
-// Submit submits an operation to the batch processor.
-func (bp *BatchProcessor) Submit(op *BatchOperation) {
+// errBatchProcessorStopped is returned for operations submitted to or awaited on a stopped batch processor.
+var errBatchProcessorStopped = errors.New("the batch processor is stopped")
+
+// Submit submits an operation to the batch processor. It returns an error if ctx is done or the
+// processor is stopped before the operation is queued.
+func (bp *BatchProcessor) Submit(ctx context.Context, op *BatchOperation) error {
+	select {
+	case <-bp.done:
+		return errBatchProcessorStopped
+	default:
+	}
+
 	bp.mutex.Lock()
 	bp.results[op.ID] = op
 	bp.mutex.Unlock()
 
-	bp.operations <- op
+	select {
+	case bp.operations <- op:
+		return nil
+	case <-ctx.Done():
+		bp.forget(op.ID)
+		return ctx.Err()
+	case <-bp.done:
+		bp.forget(op.ID)
+		return errBatchProcessorStopped
+	}
 }
 
-// Wait waits for an operation to complete.
-func (bp *BatchProcessor) Wait(id string) (*BatchOperation, error) {
+// Wait waits for an operation to complete. It returns an error if ctx is done or the processor
+// is stopped before the operation completes.
+func (bp *BatchProcessor) Wait(ctx context.Context, id string) (*BatchOperation, error) {
 	bp.mutex.RLock()
 	op, exists := bp.results[id]
 	bp.mutex.RUnlock()
@@ -131,9 +183,29 @@ func (bp *BatchProcessor) Wait(id string) (*BatchOperation, error) {
 	if !exists {
 		return nil, nil
 	}
+	defer bp.forget(id)
+
+	select {
+	case <-op.Done:
+		return op, op.Error
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-bp.done:
+		// The operation may have completed just before the processor stopped
+		select {
+		case <-op.Done:
+			return op, op.Error
+		default:
+			return nil, errBatchProcessorStopped
+		}
+	}
+}
 
-	<-op.Done
-	return op, op.Error
+// forget removes the operation with id from the pending results.
+func (bp *BatchProcessor) forget(id string) {
+	bp.mutex.Lock()
+	delete(bp.results, id)
+	bp.mutex.Unlock()
 }
 
 // processBatches processes operations in batches.
@@ -158,10 +230,18 @@ func (bp *BatchProcessor) processBatches() {
 				bp.executeBatch(batch)
 				batch = make([]*BatchOperation, 0, bp.batchSize)
 			}
+
+		case <-bp.done:
+			return
 		}
 	}
 }
 
+// Stop stops processing batches. Pending and later calls to Submit and Wait return an error.
+func (bp *BatchProcessor) Stop() {
+	bp.stopOnce.Do(func() { close(bp.done) })
+}
+
 // executeBatch executes a batch of operations.
 func (bp *BatchProcessor) executeBatch(batch []*BatchOperation) {
 	// Group operations by type for more efficient processing
@@ -174,13 +254,13 @@ func (bp *BatchProcessor) executeBatch(batch []*BatchOperation) {
 	// Execute each group
 	for operationType, ops := range operationGroups {
 		switch operationType {
-		case "get_application":
+		case batchGetApplication:
 			bp.executeGetApplicationBatch(ops)
-		case "get_database":
+		case batchGetDatabase:
 			bp.executeGetDatabaseBatch(ops)
-		case "get_static_site":
+		case batchGetStaticSite:
 			bp.executeGetStaticSiteBatch(ops)
-		case "get_pipeline":
+		case batchGetPipeline:
 			bp.executeGetPipelineBatch(ops)
 		default:
 			// Execute individually if no batch support
@@ -193,41 +273,78 @@ func (bp *BatchProcessor) executeBatch(batch []*BatchOperation) {
 
 // executeGetApplicationBatch executes a batch of get application operations.
 func (bp *BatchProcessor) executeGetApplicationBatch(ops []*BatchOperation) {
-	// In a real implementation, this would make a batch API call
-	// For now, we'll execute individually but could be optimized
-	for _, op := range ops {
-		bp.executeIndividualOperation(op)
-	}
+	bp.executeGetBatch(ops, func(ctx context.Context, id string) (interface{}, error) {
+		return bp.client.Applications.Get(ctx, id)
+	})
 }
 
 // executeGetDatabaseBatch executes a batch of get database operations.
 func (bp *BatchProcessor) executeGetDatabaseBatch(ops []*BatchOperation) {
-	// In a real implementation, this would make a batch API call
-	for _, op := range ops {
-		bp.executeIndividualOperation(op)
-	}
+	bp.executeGetBatch(ops, func(ctx context.Context, id string) (interface{}, error) {
+		return bp.client.Databases.Get(ctx, id)
+	})
 }
 
 // executeGetStaticSiteBatch executes a batch of get static site operations.
 func (bp *BatchProcessor) executeGetStaticSiteBatch(ops []*BatchOperation) {
-	// In a real implementation, this would make a batch API call
-	for _, op := range ops {
-		bp.executeIndividualOperation(op)
-	}
+	bp.executeGetBatch(ops, func(ctx context.Context, id string) (interface{}, error) {
+		return bp.client.StaticSites.Get(ctx, id)
+	})
 }
 
 // executeGetPipelineBatch executes a batch of get pipeline operations.
 func (bp *BatchProcessor) executeGetPipelineBatch(ops []*BatchOperation) {
-	// In a real implementation, this would make a batch API call
+	bp.executeGetBatch(ops, func(ctx context.Context, id string) (interface{}, error) {
+		return bp.client.Pipelines.Get(ctx, id)
+	})
+}
+
+// executeGetBatch collects the IDs requested by ops and gets each resource once, sharing the
+// result between operations for the same ID. The API has no bulk endpoints, so distinct IDs
+// are still fetched one by one. Every operation is marked done afterwards.
+func (bp *BatchProcessor) executeGetBatch(ops []*BatchOperation, get func(ctx context.Context, id string) (interface{}, error)) {
+	byID := make(map[string][]*BatchOperation)
+	var ids []string
 	for _, op := range ops {
-		bp.executeIndividualOperation(op)
+		id, ok := op.Parameters.(string)
+		if !ok {
+			op.Error = fmt.Errorf("expected the %s parameters to be a resource ID, got %T", op.Operation, op.Parameters)
+			close(op.Done)
+			continue
+		}
+		if _, seen := byID[id]; !seen {
+			ids = append(ids, id)
+		}
+		byID[id] = append(byID[id], op)
+	}
+
+	for _, id := range ids {
+		group := byID[id]
+
+		ctx := group[0].Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		var result interface{}
+		var err error
+		if bp.client == nil {
+			err = fmt.Errorf("the batch processor has no API client")
+		} else {
+			result, err = get(ctx, id)
+		}
+
+		for _, op := range group {
+			op.Result = result
+			op.Error = err
+			close(op.Done)
+		}
 	}
 }
 
-// executeIndividualOperation executes a single operation.
+// executeIndividualOperation executes a single operation the processor has no batch support for.
 func (bp *BatchProcessor) executeIndividualOperation(op *BatchOperation) {
-	// This would contain the actual API call logic
-	// For now, we'll just mark it as done
+	op.Error = fmt.Errorf("unsupported batch operation %q", op.Operation)
 	close(op.Done)
 }
 
@@ -237,6 +354,8 @@ type RateLimiter struct {
 	ticker    *time.Ticker
 	rateLimit int
 	interval  time.Duration
+	done      chan struct{}
+	stopOnce  sync.Once
 }
 
 // NewRateLimiter creates a new rate limiter.
@@ -246,6 +365,7 @@ func NewRateLimiter(rateLimit int, interval time.Duration) *RateLimiter {
 		ticker:    time.NewTicker(interval),
 		rateLimit: rateLimit,
 		interval:  interval,
+		done:      make(chan struct{}),
 	}
 
 	// Fill the token bucket initially
@@ -271,35 +391,82 @@ func (rl *RateLimiter) Wait(ctx context.Context) error {
 
 // refillTokens refills the token bucket at the specified interval.
 func (rl *RateLimiter) refillTokens() {
-	for range rl.ticker.C {
+	for {
 		select {
-		case rl.tokens <- struct{}{}:
-		default:
-			// Token bucket is full, skip
+		case <-rl.ticker.C:
+			select {
+			case rl.tokens <- struct{}{}:
+			default:
+				// Token bucket is full, skip
+			}
+		case <-rl.done:
+			return
 		}
 	}
 }
 
 // Stop stops the rate limiter.
 func (rl *RateLimiter) Stop() {
-	rl.ticker.Stop()
+	rl.stopOnce.Do(func() {
+		rl.ticker.Stop()
+		close(rl.done)
+	})
 }
 
 // PerformanceOptimizedClient wraps the Sevalla API client with performance optimizations.
 type PerformanceOptimizedClient struct {
 	client         *sevallaapi.Client
+	config         *PerformanceConfig
 	cache          *ProviderCache
 	batchProcessor *BatchProcessor
 	rateLimiter    *RateLimiter
+	nextBatchID    atomic.Uint64
+	done           chan struct{}
+	stopped        sync.WaitGroup
+	stopOnce       sync.Once
 }
 
-// NewPerformanceOptimizedClient creates a new performance optimized client.
+// cacheCleanupInterval is how often expired entries are removed from the cache.
+var cacheCleanupInterval = time.Minute
+
+// NewPerformanceOptimizedClient creates a new performance optimized client using the default
+// performance configuration. Call Stop once it is no longer used to end its background goroutines.
 func NewPerformanceOptimizedClient(client *sevallaapi.Client) *PerformanceOptimizedClient {
-	return &PerformanceOptimizedClient{
+	return NewPerformanceOptimizedClientWithConfig(client, DefaultPerformanceConfig())
+}
+
+// NewPerformanceOptimizedClientWithConfig creates a new performance optimized client, sizing
+// its batches from config.
+func NewPerformanceOptimizedClientWithConfig(client *sevallaapi.Client, config *PerformanceConfig) *PerformanceOptimizedClient {
+	poc := &PerformanceOptimizedClient{
 		client:         client,
+		config:         config,
 		cache:          NewProviderCache(),
-		batchProcessor: NewBatchProcessor(10, 100*time.Millisecond),
+		batchProcessor: NewBatchProcessor(client, config.BatchSize, config.BatchTimeout),
 		rateLimiter:    NewRateLimiter(10, 1*time.Second),
+		done:           make(chan struct{}),
+	}
+
+	poc.stopped.Add(1)
+	go poc.clearExpiredCache()
+
+	return poc
+}
+
+// clearExpiredCache periodically removes expired cache entries until the client is stopped.
+func (poc *PerformanceOptimizedClient) clearExpiredCache() {
+	defer poc.stopped.Done()
+
+	ticker := time.NewTicker(cacheCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			poc.cache.ClearExpired()
+		case <-poc.done:
+			return
+		}
 	}
 }
 
@@ -322,7 +489,13 @@ func (poc *PerformanceOptimizedClient) GetApplicationCached(ctx context.Context,
 
 	// Make API call
 	tflog.Debug(ctx, "Making API call for application", map[string]interface{}{"id": id})
-	app, err := sevallaapi.NewApplicationService(poc.client).Get(ctx, id)
+	var app *sevallaapi.Application
+	var err error
+	if poc.config.BatchEnabled {
+		app, err = poc.getApplicationBatched(ctx, id)
+	} else {
+		app, err = sevallaapi.NewApplicationService(poc.client).Get(ctx, id)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -333,6 +506,30 @@ func (poc *PerformanceOptimizedClient) GetApplicationCached(ctx context.Context,
 	return app, nil
 }
 
+// getApplicationBatched gets an application through the batch processor, so concurrent gets of
+// the same application in one batch window share a single API call.
+func (poc *PerformanceOptimizedClient) getApplicationBatched(ctx context.Context, id string) (*sevallaapi.Application, error) {
+	op := &BatchOperation{
+		ID:         fmt.Sprintf("%s:%d", batchGetApplication, poc.nextBatchID.Add(1)),
+		Operation:  batchGetApplication,
+		Parameters: id,
+		Done:       make(chan bool),
+		Context:    ctx,
+	}
+	if err := poc.batchProcessor.Submit(ctx, op); err != nil {
+		return nil, err
+	}
+
+	if _, err := poc.batchProcessor.Wait(ctx, op.ID); err != nil {
+		return nil, err
+	}
+	app, ok := op.Result.(*sevallaapi.Application)
+	if !ok {
+		return nil, fmt.Errorf("unexpected batch result %T for application %s", op.Result, id)
+	}
+	return app, nil
+}
+
 // GetDatabaseCached gets a database with caching.
 func (poc *PerformanceOptimizedClient) GetDatabaseCached(ctx context.Context, id string) (*sevallaapi.Database, error) {
 	cacheKey := "database:" + id
@@ -423,13 +620,81 @@ func (poc *PerformanceOptimizedClient) GetPipelineCached(ctx context.Context, id
 	return pipeline, nil
 }
 
-// InvalidateCache invalidates cache entries for a specific resource type.
+// GetApplicationsCached lists the company's applications with caching.
+func (poc *PerformanceOptimizedClient) GetApplicationsCached(ctx context.Context, companyID string) ([]sevallaapi.ApplicationListItem, error) {
+	cacheKey := listCacheKey("application", companyID)
+
+	// Check cache first
+	if cached, found := poc.cache.Get(cacheKey); found {
+		tflog.Debug(ctx, "Applications retrieved from cache", map[string]interface{}{"company_id": companyID})
+		if apps, ok := cached.([]sevallaapi.ApplicationListItem); ok {
+			return apps, nil
+		}
+	}
+
+	// Wait for rate limiter
+	if err := poc.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	// Make API call
+	tflog.Debug(ctx, "Making API call for applications", map[string]interface{}{"company_id": companyID})
+	apps, err := sevallaapi.NewApplicationService(poc.client).List(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	poc.cache.Set(cacheKey, apps, 5*time.Minute)
+
+	return apps, nil
+}
+
+// GetDatabasesCached lists the company's databases with caching.
+func (poc *PerformanceOptimizedClient) GetDatabasesCached(ctx context.Context, companyID string) ([]sevallaapi.DatabaseListItem, error) {
+	cacheKey := listCacheKey("database", companyID)
+
+	// Check cache first
+	if cached, found := poc.cache.Get(cacheKey); found {
+		tflog.Debug(ctx, "Databases retrieved from cache", map[string]interface{}{"company_id": companyID})
+		if dbs, ok := cached.([]sevallaapi.DatabaseListItem); ok {
+			return dbs, nil
+		}
+	}
+
+	// Wait for rate limiter
+	if err := poc.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	// Make API call
+	tflog.Debug(ctx, "Making API call for databases", map[string]interface{}{"company_id": companyID})
+	dbs, err := sevallaapi.NewDatabaseService(poc.client).List(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	poc.cache.Set(cacheKey, dbs, 5*time.Minute)
+
+	return dbs, nil
+}
+
+// InvalidateCache invalidates cache entries for a specific resource type. Call it whenever a
+// resource is created, updated or deleted. Cached lists of that type are dropped for every
+// company, since the ID alone does not say which company the resource belongs to.
 func (poc *PerformanceOptimizedClient) InvalidateCache(resourceType, id string) {
 	cacheKey := resourceType + ":" + id
 	poc.cache.mutex.Lock()
-	defer poc.cache.mutex.Unlock()
-
 	delete(poc.cache.cache, cacheKey)
+	poc.cache.mutex.Unlock()
+
+	poc.cache.DeletePrefix(listCacheKey(resourceType, ""))
+}
+
+// listCacheKey returns the cache key of a company's list of resources, e.g. applications:list:<company>.
+func listCacheKey(resourceType, companyID string) string {
+	return resourceType + "s:list:" + companyID
 }
 
 // ClearCache clears all cache entries.
@@ -437,8 +702,14 @@ func (poc *PerformanceOptimizedClient) ClearCache() {
 	poc.cache.Clear()
 }
 
-// Stop stops all performance optimization components.
+// Stop stops all performance optimization components and waits for the cache cleanup to end.
+// It is safe to call more than once.
 func (poc *PerformanceOptimizedClient) Stop() {
-	poc.rateLimiter.Stop()
-	poc.cache.Clear()
+	poc.stopOnce.Do(func() {
+		close(poc.done)
+		poc.stopped.Wait()
+		poc.batchProcessor.Stop()
+		poc.rateLimiter.Stop()
+		poc.cache.Clear()
+	})
 }
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+	"go.uber.org/goleak"
+)
+
+func TestPerformanceOptimizedClient_GetDatabasesCached(t *testing.T) {
+	ctx := context.Background()
+	server := sevallaapitest.NewServer(t)
+	client := sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})
+	poc := NewPerformanceOptimizedClient(client)
+	t.Cleanup(poc.Stop)
+
+	listRequests := func() int {
+		var count int
+		for _, req := range server.Requests() {
+			if req.Method == http.MethodGet && req.Path == "/databases" {
+				count++
+			}
+		}
+		return count
+	}
+
+	if _, err := poc.GetDatabasesCached(ctx, sevallaapitest.CompanyID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Listing may take more than one request to page through the results
+	perList := listRequests()
+
+	if _, err := poc.GetDatabasesCached(ctx, sevallaapitest.CompanyID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := listRequests(); got != perList {
+		t.Fatalf("expected the second list to be served from cache, got %d list requests", got-perList)
+	}
+
+	db, err := client.Databases.Create(ctx, sevallaapi.CreateDatabaseRequest{CompanyID: sevallaapitest.CompanyID})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	poc.InvalidateCache("database", db.Database.ID)
+
+	if _, err := poc.GetDatabasesCached(ctx, sevallaapitest.CompanyID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := listRequests(); got != 2*perList {
+		t.Errorf("expected the list to be fetched again after a create, got %d list requests", got-perList)
+	}
+}
+
+func TestPerformanceOptimizedClient_InvalidateCacheKeepsOtherTypes(t *testing.T) {
+	ctx := context.Background()
+	server := sevallaapitest.NewServer(t)
+	poc := NewPerformanceOptimizedClient(sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"}))
+	t.Cleanup(poc.Stop)
+
+	if _, err := poc.GetApplicationsCached(ctx, sevallaapitest.CompanyID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	poc.InvalidateCache("database", sevallaapitest.DatabaseID)
+
+	if _, found := poc.cache.Get(listCacheKey("application", sevallaapitest.CompanyID)); !found {
+		t.Error("expected invalidating databases to keep the cached application list")
+	}
+}
+
+func TestPerformanceOptimizedClient_ClearsExpiredEntries(t *testing.T) {
+	cacheCleanupInterval = time.Millisecond
+	t.Cleanup(func() { cacheCleanupInterval = time.Minute })
+
+	poc := NewPerformanceOptimizedClient(sevallaapi.NewClient(sevallaapi.Config{BaseURL: "http://localhost", Token: "test-token"}))
+	t.Cleanup(poc.Stop)
+	poc.cache.Set("application:expired", "value", time.Nanosecond)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		poc.cache.mutex.RLock()
+		_, exists := poc.cache.cache["application:expired"]
+		poc.cache.mutex.RUnlock()
+		if !exists {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the expired entry to be removed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPerformanceOptimizedClient_StopLeaksNoGoroutines(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	poc := NewPerformanceOptimizedClient(sevallaapi.NewClient(sevallaapi.Config{BaseURL: "http://localhost", Token: "test-token"}))
+	poc.Stop()
+	poc.Stop()
+}
+
+func TestBatchProcessor_StopLeaksNoGoroutines(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	bp := NewBatchProcessor(nil, 1, time.Minute)
+	rl := NewRateLimiter(1, time.Millisecond)
+	bp.Stop()
+	rl.Stop()
+	rl.Stop()
+}
+
+func TestPerformanceOptimizedClient_GetApplicationCachedBatches(t *testing.T) {
+	const gets = 5
+
+	server := sevallaapitest.NewServer(t)
+	config := DefaultPerformanceConfig()
+	config.BatchSize = gets
+	config.BatchTimeout = time.Minute
+	poc := NewPerformanceOptimizedClientWithConfig(sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"}), config)
+	t.Cleanup(poc.Stop)
+
+	// The batch is only flushed once it is full, so every get lands in the same batch window
+	var wg sync.WaitGroup
+	errs := make([]error, gets)
+	for i := range gets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			app, err := poc.GetApplicationCached(context.Background(), sevallaapitest.ApplicationID)
+			if err == nil && app.App.ID != sevallaapitest.ApplicationID {
+				t.Errorf("expected application %q, got %q", sevallaapitest.ApplicationID, app.App.ID)
+			}
+			errs[i] = err
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if got := len(server.Requests()); got != 1 {
+		t.Errorf("expected %d concurrent gets to share one API call, got %d requests", gets, got)
+	}
+}
+
+func TestBatchProcessor_SetsErrors(t *testing.T) {
+	server := sevallaapitest.NewServer(t)
+	server.HandleJSON(http.MethodGet, "/databases/{id}", http.StatusNotFound, `{"message":"Database not found","status":404}`)
+	bp := NewBatchProcessor(sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"}), 1, time.Minute)
+	t.Cleanup(bp.Stop)
+
+	op := &BatchOperation{ID: "op", Operation: batchGetDatabase, Parameters: "missing", Done: make(chan bool)}
+	if err := bp.Submit(context.Background(), op); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := bp.Wait(context.Background(), op.ID); err == nil {
+		t.Error("expected the API error to be returned")
+	}
+
+	unsupported := &BatchOperation{ID: "unsupported", Operation: "delete_database", Done: make(chan bool)}
+	if err := bp.Submit(context.Background(), unsupported); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := bp.Wait(context.Background(), unsupported.ID); err == nil {
+		t.Error("expected an error for an unsupported operation")
+	}
+}
+
+func TestBatchProcessor_StoppedReturnsErrors(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	// The batch is never flushed, so Wait only returns once the processor stops
+	bp := NewBatchProcessor(nil, 2, time.Hour)
+	pending := &BatchOperation{ID: "pending", Operation: batchGetApplication, Parameters: "app", Done: make(chan bool)}
+	if err := bp.Submit(context.Background(), pending); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := bp.Wait(context.Background(), pending.ID)
+		errs <- err
+	}()
+	bp.Stop()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, errBatchProcessorStopped) {
+			t.Errorf("expected Wait to return %v, got %v", errBatchProcessorStopped, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait did not return after Stop")
+	}
+
+	late := &BatchOperation{ID: "late", Operation: batchGetApplication, Parameters: "app", Done: make(chan bool)}
+	if err := bp.Submit(context.Background(), late); !errors.Is(err, errBatchProcessorStopped) {
+		t.Errorf("expected Submit to return %v, got %v", errBatchProcessorStopped, err)
+	}
+}
+
+func TestBatchProcessor_WaitHonoursContext(t *testing.T) {
+	bp := NewBatchProcessor(nil, 2, time.Hour)
+	t.Cleanup(bp.Stop)
+
+	op := &BatchOperation{ID: "op", Operation: batchGetApplication, Parameters: "app", Done: make(chan bool)}
+	if err := bp.Submit(context.Background(), op); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := bp.Wait(ctx, op.ID); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Wait to return %v, got %v", context.Canceled, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -31,6 +32,14 @@ type PipelineDataSourceModel struct {
 	AutoDeploy types.Bool   `tfsdk:"auto_deploy"`
 	CreatedAt  types.String `tfsdk:"created_at"`
 	UpdatedAt  types.String `tfsdk:"updated_at"`
+	Stages     types.List   `tfsdk:"stages"`
+}
+
+// pipelineStageAttrTypes describes the object type of a pipeline stage in state.
+var pipelineStageAttrTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"display_name": types.StringType,
+	"type":         types.StringType,
 }
 
 func (d *PipelineDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -39,7 +48,9 @@ func (d *PipelineDataSource) Metadata(ctx context.Context, req datasource.Metada
 
 func (d *PipelineDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Data source for fetching information about a Sevalla deployment pipeline.",
+		MarkdownDescription: "Data source for fetching information about a Sevalla deployment pipeline. " +
+			"The API only returns the name and stages of a pipeline, so `app_id`, `branch`, `auto_deploy`, " +
+			"`created_at` and `updated_at` are always null.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -70,6 +81,26 @@ func (d *PipelineDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				Computed:            true,
 				MarkdownDescription: "The timestamp when the pipeline was last updated.",
 			},
+			"stages": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The stages of the pipeline, in order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The stage ID.",
+						},
+						"display_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The display name of the stage.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The stage type: `preview` or `standard`.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -110,13 +141,30 @@ func (d *PipelineDataSource) Read(ctx context.Context, req datasource.ReadReques
 	// Map response back to schema
 	data.ID = types.StringValue(pipeline.ID)
 	data.Name = types.StringValue(pipeline.DisplayName)
-	// Set other values from API response
-	data.AppID = types.StringValue("")      // Set from API response when available
-	data.Branch = types.StringValue("main") // Set from API response when available
-	data.AutoDeploy = types.BoolValue(true) // Set from API response when available
-	data.CreatedAt = types.StringValue("")  // Set from API response when available
-	data.UpdatedAt = types.StringValue("")  // Set from API response when available
+	data.Stages = pipelineStagesValue(pipeline.Stages)
+	// The API only returns the name and stages of a pipeline
+	data.AppID = types.StringNull()
+	data.Branch = types.StringNull()
+	data.AutoDeploy = types.BoolNull()
+	data.CreatedAt = types.StringNull()
+	data.UpdatedAt = types.StringNull()
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// pipelineStagesValue converts API pipeline stages into their state list.
+func pipelineStagesValue(stages []sevallaapi.PipelineStage) types.List {
+	elements := make([]attr.Value, 0, len(stages))
+	for _, stage := range stages {
+		obj, _ := types.ObjectValue(pipelineStageAttrTypes, map[string]attr.Value{
+			"id":           types.StringValue(stage.ID),
+			"display_name": types.StringValue(stage.DisplayName),
+			"type":         types.StringValue(stage.Type),
+		})
+		elements = append(elements, obj)
+	}
+
+	list, _ := types.ListValue(types.ObjectType{AttrTypes: pipelineStageAttrTypes}, elements)
+	return list
+}
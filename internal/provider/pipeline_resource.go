@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -11,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
@@ -38,6 +42,14 @@ type PipelineResourceModel struct {
 	AutoDeploy types.Bool   `tfsdk:"auto_deploy"`
 	CreatedAt  types.String `tfsdk:"created_at"`
 	UpdatedAt  types.String `tfsdk:"updated_at"`
+	Stages     types.List   `tfsdk:"stages"`
+}
+
+// PipelineStageModel describes a stage of a pipeline.
+type PipelineStageModel struct {
+	ID          types.String `tfsdk:"id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Type        types.String `tfsdk:"type"`
 }
 
 func (r *PipelineResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -87,6 +99,33 @@ func (r *PipelineResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:            true,
 				MarkdownDescription: "The timestamp when the pipeline was last updated.",
 			},
+			"stages": schema.ListNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "The ordered stages of the pipeline. Stages are matched to existing ones by `display_name`, " +
+					"so display names must be unique and reordering stages keeps their IDs. Stages are left unmanaged when omitted.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID assigned to the stage by Sevalla.",
+						},
+						"display_name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The display name of the stage.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The stage type: `preview` for preview apps or `standard`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("preview", "standard"),
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -117,9 +156,15 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	stages := expandPipelineStages(ctx, data.Stages, types.ListNull(types.ObjectType{AttrTypes: pipelineStageAttrTypes}), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Create the pipeline
 	createReq := sevallaapi.CreatePipelineRequest{
 		DisplayName: data.Name.ValueString(),
+		Stages:      stages,
 		// Add other fields as needed based on API specification
 	}
 
@@ -141,6 +186,7 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 	}
 	data.CreatedAt = types.StringValue("") // Set from API response when available
 	data.UpdatedAt = types.StringValue("") // Set from API response when available
+	data.Stages = plannedPipelineStages(ctx, data.Stages, pipeline.Stages, &resp.Diagnostics)
 
 	tflog.Trace(ctx, "created a pipeline resource")
 
@@ -167,6 +213,7 @@ func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 	// Map response back to schema
 	data.ID = types.StringValue(pipeline.ID)
 	data.Name = types.StringValue(pipeline.DisplayName)
+	data.Stages = flattenPipelineStages(ctx, data.Stages, pipeline.Stages)
 	// Update other computed values from API response
 
 	// Save updated data into Terraform state
@@ -174,10 +221,16 @@ func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data PipelineResourceModel
+	var data, state PipelineResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stages := expandPipelineStages(ctx, data.Stages, state.Stages, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -185,6 +238,7 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 	// Update the pipeline
 	updateReq := sevallaapi.UpdatePipelineRequest{
 		DisplayName: stringPointer(data.Name.ValueString()),
+		Stages:      stages,
 		// Add other updateable fields based on API specification
 	}
 
@@ -198,6 +252,7 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 	data.ID = types.StringValue(pipeline.ID)
 	data.Name = types.StringValue(pipeline.DisplayName)
 	data.UpdatedAt = types.StringValue("") // Set from API response when available
+	data.Stages = plannedPipelineStages(ctx, data.Stages, pipeline.Stages, &resp.Diagnostics)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -223,3 +278,125 @@ func (r *PipelineResource) Delete(ctx context.Context, req resource.DeleteReques
 func (r *PipelineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// expandPipelineStages converts the planned stages for the API. Stages keep the ID of the
+// prior stage with the same display name, so reordering does not recreate them.
+func expandPipelineStages(ctx context.Context, planned, prior types.List, diags *diag.Diagnostics) *[]sevallaapi.PipelineStageRequest {
+	if planned.IsNull() || planned.IsUnknown() {
+		return nil
+	}
+
+	var plannedStages []PipelineStageModel
+	diags.Append(planned.ElementsAs(ctx, &plannedStages, false)...)
+
+	priorIDs := make(map[string]string)
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var priorStages []PipelineStageModel
+		diags.Append(prior.ElementsAs(ctx, &priorStages, false)...)
+		for _, stage := range priorStages {
+			priorIDs[stage.DisplayName.ValueString()] = stage.ID.ValueString()
+		}
+	}
+	if diags.HasError() {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(plannedStages))
+	stages := make([]sevallaapi.PipelineStageRequest, 0, len(plannedStages))
+	for i, stage := range plannedStages {
+		displayName := stage.DisplayName.ValueString()
+		if seen[displayName] {
+			diags.AddAttributeError(
+				path.Root("stages").AtListIndex(i).AtName("display_name"),
+				"Duplicate Pipeline Stage",
+				fmt.Sprintf("The stage display name %q is used more than once. Stages are matched by display name, so it must be unique.", displayName),
+			)
+			continue
+		}
+		seen[displayName] = true
+
+		stages = append(stages, sevallaapi.PipelineStageRequest{
+			ID:          priorIDs[displayName],
+			DisplayName: displayName,
+			Type:        stage.Type.ValueString(),
+		})
+	}
+
+	return &stages
+}
+
+// plannedPipelineStages returns the planned stages with the IDs the API assigned, matched by
+// display name. The planned list is kept as it is, because state after apply must match the
+// plan; stages added outside Terraform show up as drift on the next read. A planned stage the
+// API did not return gets a null ID.
+func plannedPipelineStages(ctx context.Context, planned types.List, remote []sevallaapi.PipelineStage, diags *diag.Diagnostics) types.List {
+	objectType := types.ObjectType{AttrTypes: pipelineStageAttrTypes}
+	if planned.IsNull() || planned.IsUnknown() {
+		return types.ListNull(objectType)
+	}
+
+	var plannedStages []PipelineStageModel
+	diags.Append(planned.ElementsAs(ctx, &plannedStages, false)...)
+	if diags.HasError() {
+		return types.ListNull(objectType)
+	}
+
+	remoteIDs := make(map[string]string, len(remote))
+	for _, stage := range remote {
+		remoteIDs[stage.DisplayName] = stage.ID
+	}
+
+	elements := make([]attr.Value, 0, len(plannedStages))
+	for _, stage := range plannedStages {
+		id := types.StringNull()
+		if remoteID, ok := remoteIDs[stage.DisplayName.ValueString()]; ok {
+			id = types.StringValue(remoteID)
+		}
+		element, d := types.ObjectValue(pipelineStageAttrTypes, map[string]attr.Value{
+			"id":           id,
+			"display_name": stage.DisplayName,
+			"type":         stage.Type,
+		})
+		diags.Append(d...)
+		elements = append(elements, element)
+	}
+
+	list, d := types.ListValue(objectType, elements)
+	diags.Append(d...)
+	return list
+}
+
+// flattenPipelineStages orders the API stages like the ones in state for Read, matching them by display
+// name. Stages missing from state are appended so they show up as drift. Unmanaged stages stay null.
+func flattenPipelineStages(ctx context.Context, configured types.List, remote []sevallaapi.PipelineStage) types.List {
+	objectType := types.ObjectType{AttrTypes: pipelineStageAttrTypes}
+	if configured.IsNull() {
+		return types.ListNull(objectType)
+	}
+
+	var configuredStages []PipelineStageModel
+	if diags := configured.ElementsAs(ctx, &configuredStages, false); diags.HasError() {
+		return types.ListNull(objectType)
+	}
+
+	remoteByName := make(map[string]sevallaapi.PipelineStage, len(remote))
+	for _, stage := range remote {
+		remoteByName[stage.DisplayName] = stage
+	}
+
+	ordered := make([]sevallaapi.PipelineStage, 0, len(remote))
+	matched := make(map[string]bool, len(configuredStages))
+	for _, stage := range configuredStages {
+		if remoteStage, ok := remoteByName[stage.DisplayName.ValueString()]; ok && !matched[remoteStage.DisplayName] {
+			ordered = append(ordered, remoteStage)
+			matched[remoteStage.DisplayName] = true
+		}
+	}
+	for _, stage := range remote {
+		if !matched[stage.DisplayName] {
+			ordered = append(ordered, stage)
+		}
+	}
+
+	return pipelineStagesValue(ordered)
+}
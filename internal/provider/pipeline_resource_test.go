@@ -1,10 +1,20 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
 )
 
 func TestAccPipelineResource(t *testing.T) {
@@ -154,3 +164,135 @@ resource "sevalla_pipeline" "test" {
 }
 `, name, appID, branch)
 }
+
+func TestPipelineResourceUpdate_Stages(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	server.HandleJSON(http.MethodPut, "/pipelines/{id}", http.StatusOK, `{
+  "id": "pipeline-1",
+  "display_name": "my-pipeline",
+  "stages": [
+    {"id": "stage-1", "display_name": "Staging", "type": "standard"},
+    {"id": "stage-2", "display_name": "Production", "type": "standard"},
+    {"id": "stage-3", "display_name": "Preview", "type": "preview"}
+  ]
+}`)
+	r := &PipelineResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	stagesType, ok := objectType.AttributeTypes["stages"].(tftypes.List)
+	if !ok {
+		t.Fatalf("unexpected stages type %s", objectType.AttributeTypes["stages"])
+	}
+	stageType, ok := stagesType.ElementType.(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected stage type %s", stagesType.ElementType)
+	}
+
+	stage := func(id interface{}, displayName string) tftypes.Value {
+		return tftypes.NewValue(stageType, map[string]tftypes.Value{
+			"id":           tftypes.NewValue(tftypes.String, id),
+			"display_name": tftypes.NewValue(tftypes.String, displayName),
+			"type":         tftypes.NewValue(tftypes.String, "standard"),
+		})
+	}
+	pipeline := func(stages ...tftypes.Value) tftypes.Value {
+		values := testNullValues(objectType)
+		values["id"] = tftypes.NewValue(tftypes.String, sevallaapitest.PipelineID)
+		values["name"] = tftypes.NewValue(tftypes.String, "my-pipeline")
+		values["stages"] = tftypes.NewValue(stagesType, stages)
+		return tftypes.NewValue(objectType, values)
+	}
+
+	// Production is added in front of the existing Staging stage. The Preview stage was added
+	// outside Terraform and must not appear in state until the next read.
+	req := fwresource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: pipeline(stage(tftypes.UnknownValue, "Production"), stage(tftypes.UnknownValue, "Staging"))},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: pipeline(stage("stage-1", "Staging"))},
+	}
+	resp := &fwresource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Update(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	body := string(server.Requests()[0].Body)
+	if !strings.Contains(body, `{"display_name":"Production","type":"standard"}`) ||
+		!strings.Contains(body, `{"id":"stage-1","display_name":"Staging","type":"standard"}`) {
+		t.Errorf("expected the existing stage to keep its ID and the new stage to have none, got %s", body)
+	}
+
+	var data PipelineResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	var stages []PipelineStageModel
+	resp.Diagnostics.Append(data.Stages.ElementsAs(ctx, &stages, false)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if len(stages) != 2 ||
+		stages[0].DisplayName.ValueString() != "Production" || stages[0].ID.ValueString() != "stage-2" ||
+		stages[1].DisplayName.ValueString() != "Staging" || stages[1].ID.ValueString() != "stage-1" {
+		t.Errorf("expected the stages in configured order with their assigned IDs, got %+v", stages)
+	}
+
+	// Removing every stage sends an empty list rather than leaving the stages unchanged
+	req = fwresource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: pipeline()},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: pipeline(stage("stage-1", "Staging"))},
+	}
+	resp = &fwresource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Update(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	last, _ := server.LastRequest()
+	if !strings.Contains(string(last.Body), `"stages":[]`) {
+		t.Errorf("expected an empty stages list to be sent, got %s", last.Body)
+	}
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Stages.IsNull() || len(data.Stages.Elements()) != 0 {
+		t.Errorf("expected an empty stages list in state, got %s (%v)", data.Stages, resp.Diagnostics)
+	}
+
+	// A read reports the stage added outside Terraform as drift
+	server.HandleJSON(http.MethodGet, "/pipelines/{id}", http.StatusOK, `{
+  "id": "pipeline-1",
+  "display_name": "my-pipeline",
+  "stages": [
+    {"id": "stage-1", "display_name": "Staging", "type": "standard"},
+    {"id": "stage-3", "display_name": "Preview", "type": "preview"}
+  ]
+}`)
+	prior := tfsdk.State{Schema: schemaResp.Schema, Raw: pipeline(stage("stage-1", "Staging"))}
+	readResp := &fwresource.ReadResponse{State: prior}
+	r.Read(ctx, fwresource.ReadRequest{State: prior}, readResp)
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &data)...)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+	if got := len(data.Stages.Elements()); got != 2 {
+		t.Errorf("expected the extra stage to show up as drift, got %d stages", got)
+	}
+}
+
+func TestExpandPipelineStages_Duplicate(t *testing.T) {
+	ctx := context.Background()
+
+	planned := pipelineStagesValue([]sevallaapi.PipelineStage{
+		{DisplayName: "Staging", Type: "standard"},
+		{DisplayName: "Staging", Type: "preview"},
+	})
+
+	var diags diag.Diagnostics
+	expandPipelineStages(ctx, planned, types.ListNull(types.ObjectType{AttrTypes: pipelineStageAttrTypes}), &diags)
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Duplicate Pipeline Stage" {
+		t.Errorf("expected a duplicate stage error, got %v", diags)
+	}
+}
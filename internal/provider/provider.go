@@ -2,31 +2,59 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
 )
 
 var _ provider.Provider = &SevallaProvider{}
+var _ provider.ProviderWithFunctions = &SevallaProvider{}
 
 type SevallaProvider struct {
 	version string
 }
 
 type SevallaProviderModel struct {
-	Token   types.String `tfsdk:"token"`
-	BaseURL types.String `tfsdk:"base_url"`
+	Token               types.String `tfsdk:"token"`
+	BaseURL             types.String `tfsdk:"base_url"`
+	ConditionalUpdates  types.Bool   `tfsdk:"conditional_updates"`
+	UserAgentSuffix     types.String `tfsdk:"user_agent_suffix"`
+	ProxyURL            types.String `tfsdk:"proxy_url"`
+	InsecureSkipVerify  types.Bool   `tfsdk:"insecure_skip_verify"`
+	OperationDeadline   types.String `tfsdk:"operation_deadline"`
+	RequestTimeout      types.String `tfsdk:"request_timeout"`
+	CompanyID           types.String `tfsdk:"company_id"`
+	SkipTokenValidation types.Bool   `tfsdk:"skip_token_validation"`
+	ExtraHeaders        types.Map    `tfsdk:"extra_headers"`
 }
 
 type SevallaProviderData struct {
 	Client *sevallaapi.Client
+	// ConditionalUpdates makes resources refuse to update when the remote
+	// object changed since it was last read.
+	ConditionalUpdates bool
+	// CompanyID is used by resources that do not set their own company_id.
+	CompanyID string
 }
 
 func New(version string) func() provider.Provider {
@@ -54,6 +82,64 @@ func (p *SevallaProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				MarkdownDescription: "The base URL for the Sevalla API. Can also be set via the `SEVALLA_BASE_URL` environment variable. Defaults to `https://api.sevalla.com`.",
 				Optional:            true,
 			},
+			"conditional_updates": schema.BoolAttribute{
+				MarkdownDescription: "Reject updates to resources that were modified outside this apply since they were last read, " +
+					"instead of silently overwriting them. Can also be set via the `SEVALLA_CONDITIONAL_UPDATES` environment variable. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the User-Agent header sent with every API request, " +
+					"for example to identify the team or pipeline running Terraform.",
+				Optional: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of an HTTP(S) proxy to send API requests through, such as `http://proxy.example.com:3128`. " +
+					"Can also be set via the `SEVALLA_PROXY_URL` environment variable. " +
+					"Defaults to the standard `HTTPS_PROXY` and `NO_PROXY` environment variables.",
+				Optional: true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verification of the API's TLS certificate. **For non-production use only**, " +
+					"for example with a TLS-intercepting proxy in a test environment. Defaults to `false`.",
+				Optional: true,
+			},
+			"operation_deadline": schema.StringAttribute{
+				MarkdownDescription: "Maximum time, as a duration such as `45m` or `2h`, that the provider spends on API requests " +
+					"and on waiting for resources, counted from when the provider is configured at the start of each Terraform command. " +
+					"Once it passes, in-flight requests and polling are aborted. Resource `timeouts` still apply, and whichever limit " +
+					"is reached first wins. Can also be set via the `SEVALLA_OPERATION_DEADLINE` environment variable. " +
+					"Defaults to no deadline.",
+				Optional: true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time, as a duration such as `90s` or `2m`, that a single API request may take. " +
+					"Can also be set via the `SEVALLA_REQUEST_TIMEOUT` environment variable. Defaults to `30s`.",
+				Optional: true,
+			},
+			"company_id": schema.StringAttribute{
+				MarkdownDescription: "The company ID used by resources that do not set their own `company_id`. " +
+					"Can also be set via the `SEVALLA_COMPANY_ID` environment variable.",
+				Optional: true,
+			},
+			"skip_token_validation": schema.BoolAttribute{
+				MarkdownDescription: "Skip checking the token against the API when the provider is configured, " +
+					"for example to plan without network access. The check also fails early when the API cannot be reached. " +
+					"Can also be set via the `SEVALLA_SKIP_TOKEN_VALIDATION` environment variable. Defaults to `false`.",
+				Optional: true,
+			},
+			"extra_headers": schema.MapAttribute{
+				MarkdownDescription: "Additional HTTP headers to send with every API request, for example when requests are " +
+					"routed through an API gateway. The `Authorization`, `Content-Type`, `Accept`, `Accept-Encoding` and `User-Agent` headers " +
+					"are set by the provider and cannot be overridden.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.NoneOfCaseInsensitive(sevallaapi.ReservedHeaders...)),
+					mapvalidator.NoNullValues(),
+				},
+			},
 		},
 	}
 }
@@ -88,6 +174,59 @@ func (p *SevallaProvider) Configure(
 		baseURL = data.BaseURL.ValueString()
 	}
 
+	normalizedBaseURL, err := parseBaseURL(baseURL)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base_url"),
+			"Invalid Base URL",
+			fmt.Sprintf("The base URL %q is not valid: %s", baseURL, err),
+		)
+		return
+	}
+	baseURL = normalizedBaseURL
+
+	proxyURL := os.Getenv("SEVALLA_PROXY_URL")
+	if !data.ProxyURL.IsNull() {
+		proxyURL = data.ProxyURL.ValueString()
+	}
+
+	operationDeadline := os.Getenv("SEVALLA_OPERATION_DEADLINE")
+	if !data.OperationDeadline.IsNull() {
+		operationDeadline = data.OperationDeadline.ValueString()
+	}
+
+	requestTimeout := os.Getenv("SEVALLA_REQUEST_TIMEOUT")
+	if !data.RequestTimeout.IsNull() {
+		requestTimeout = data.RequestTimeout.ValueString()
+	}
+
+	companyID := os.Getenv("SEVALLA_COMPANY_ID")
+	if !data.CompanyID.IsNull() {
+		companyID = data.CompanyID.ValueString()
+	}
+
+	conditionalUpdates, _ := strconv.ParseBool(os.Getenv("SEVALLA_CONDITIONAL_UPDATES"))
+	if !data.ConditionalUpdates.IsNull() {
+		conditionalUpdates = data.ConditionalUpdates.ValueBool()
+	}
+
+	skipTokenValidation, _ := strconv.ParseBool(os.Getenv("SEVALLA_SKIP_TOKEN_VALIDATION"))
+	if !data.SkipTokenValidation.IsNull() {
+		skipTokenValidation = data.SkipTokenValidation.ValueBool()
+	}
+
+	// HTTP debug logging is only an environment toggle, so it can be enabled to triage a problem
+	// without changing the configuration.
+	debugHTTP, _ := strconv.ParseBool(os.Getenv("SEVALLA_DEBUG_HTTP"))
+
+	var extraHeaders map[string]string
+	if !data.ExtraHeaders.IsNull() {
+		resp.Diagnostics.Append(data.ExtraHeaders.ElementsAs(ctx, &extraHeaders, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Check if token is provided
 	if token == "" {
 		resp.Diagnostics.AddError(
@@ -104,14 +243,102 @@ func (p *SevallaProvider) Configure(
 
 	tflog.Debug(ctx, "Creating Sevalla client")
 
+	userAgent := fmt.Sprintf("terraform-provider-sevalla/%s (terraform-plugin-framework)", p.version)
+	if suffix := data.UserAgentSuffix.ValueString(); suffix != "" {
+		userAgent += " " + suffix
+	}
+
+	performance := LoadPerformanceConfigFromEnv()
+	if err := performance.Validate(); err != nil {
+		resp.Diagnostics.AddError("Invalid Performance Configuration", err.Error())
+		return
+	}
+
+	clientConfig := sevallaapi.Config{
+		Token:           token,
+		BaseURL:         baseURL,
+		UserAgent:       userAgent,
+		MaxIdleConns:    performance.MaxIdleConns,
+		MaxConnsPerHost: performance.MaxOpenConns,
+		IdleConnTimeout: performance.ConnMaxIdleTime,
+		RequestTimeout:  performance.RequestTimeout,
+		ExtraHeaders:    extraHeaders,
+		Compression:     performance.CompressionEnabled,
+		RetryAttempts:   performance.RetryAttempts,
+		RetryDelay:      performance.RetryDelay,
+		DebugHTTP:       debugHTTP,
+	}
+
+	if requestTimeout != "" {
+		timeout, err := parsePositiveDuration(requestTimeout)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Request Timeout",
+				fmt.Sprintf("The request timeout %q is not a valid duration: %s", requestTimeout, err),
+			)
+			return
+		}
+		clientConfig.Timeout = timeout
+		clientConfig.RequestTimeout = timeout
+	}
+
+	if operationDeadline != "" {
+		deadline, err := parsePositiveDuration(operationDeadline)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("operation_deadline"),
+				"Invalid Operation Deadline",
+				fmt.Sprintf("The operation deadline %q is not a valid duration: %s", operationDeadline, err),
+			)
+			return
+		}
+		clientConfig.Deadline = time.Now().Add(deadline)
+	}
+
+	transport := sevallaapi.NewTransport(clientConfig)
+	if proxyURL != "" {
+		proxy, err := parseProxyURL(proxyURL)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Proxy URL",
+				fmt.Sprintf("The proxy URL %q is not valid: %s", proxyURL, err),
+			)
+			return
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if data.InsecureSkipVerify.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Verification Disabled",
+			"The provider will not verify the Sevalla API's TLS certificate. Do not use this in production.",
+		)
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	if clientConfig.Timeout == 0 {
+		clientConfig.Timeout = sevallaapi.DefaultTimeout
+	}
+	clientConfig.HTTPClient = &http.Client{
+		Timeout:   clientConfig.Timeout,
+		Transport: transport,
+	}
+
 	// Create API client
-	client := sevallaapi.NewClient(sevallaapi.Config{
-		Token:   token,
-		BaseURL: baseURL,
-	})
+	client := sevallaapi.NewClient(clientConfig)
+
+	if !skipTokenValidation {
+		validateToken(ctx, client, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	providerData := SevallaProviderData{
-		Client: client,
+		Client:             client,
+		ConditionalUpdates: conditionalUpdates,
+		CompanyID:          companyID,
 	}
 
 	resp.DataSourceData = providerData
@@ -120,6 +347,110 @@ func (p *SevallaProvider) Configure(
 	tflog.Info(ctx, "Configured Sevalla client", map[string]any{"success": true})
 }
 
+// tokenExpiryWarningPeriod is how long before its API key expires the provider starts warning about it.
+const tokenExpiryWarningPeriod = 7 * 24 * time.Hour
+
+// validateToken checks the token against the API, reporting an error if it is rejected
+// and a warning if it expires soon.
+func validateToken(ctx context.Context, client *sevallaapi.Client, diags *diag.Diagnostics) {
+	tflog.Debug(ctx, "Validating Sevalla API token")
+
+	auth, err := client.ValidateAuth(ctx)
+	var apiErr *sevallaapi.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+		diags.AddAttributeError(
+			path.Root("token"),
+			"Invalid API Token",
+			"The Sevalla API rejected the token, which may be invalid, revoked or expired. Please check the token "+
+				"in the provider configuration or the SEVALLA_TOKEN environment variable.",
+		)
+		return
+	}
+	if err != nil && apiErr == nil {
+		// Tell a network problem apart from an unexpected response, which Ping treats as reachable.
+		if pingErr := client.Ping(ctx); pingErr != nil {
+			diags.AddError(
+				"Sevalla API Unreachable",
+				fmt.Sprintf("Unable to reach the Sevalla API at %s, got error: %s. Check base_url and network or proxy "+
+					"access to the API, or set skip_token_validation to plan without reaching the API.", client.BaseURL, pingErr),
+			)
+			return
+		}
+	}
+	if err != nil {
+		diags.AddError(
+			"Unable to Validate Token",
+			fmt.Sprintf("Unable to validate the token with the Sevalla API, got error: %s. "+
+				"Set skip_token_validation to plan without reaching the API.", err),
+		)
+		return
+	}
+
+	if expiry, ok := auth.Expiry(); ok && time.Until(expiry) < tokenExpiryWarningPeriod {
+		diags.AddAttributeWarning(
+			path.Root("token"),
+			"API Token Expires Soon",
+			fmt.Sprintf("The API key %q expires at %s. Create a new key before then to keep Terraform working.",
+				auth.Name, expiry.UTC().Format(time.RFC3339)),
+		)
+	}
+}
+
+// missingCompanyIDDetail explains how to set a company ID when neither a resource nor the provider sets one.
+const missingCompanyIDDetail = "Set company_id on the resource, or set it in the provider configuration " +
+	"or via the SEVALLA_COMPANY_ID environment variable."
+
+// companyIDOrDefault returns a resource's company ID, falling back to the provider's company_id
+// when the resource does not set one. It returns null when neither is set.
+func companyIDOrDefault(companyID types.String, defaultCompanyID string) types.String {
+	if !companyID.IsNull() && !companyID.IsUnknown() {
+		return companyID
+	}
+	if defaultCompanyID == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(defaultCompanyID)
+}
+
+// parseBaseURL checks that an API base URL is an absolute http or https URL and strips trailing slashes.
+func parseBaseURL(raw string) (string, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("expected an http or https URL such as %s", sevallaapi.DefaultBaseURL)
+	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("expected a URL with a host such as %s", sevallaapi.DefaultBaseURL)
+	}
+	return strings.TrimRight(raw, "/"), nil
+}
+
+// parseProxyURL parses a proxy URL, requiring an absolute URL with a host.
+func parseProxyURL(raw string) (*url.URL, error) {
+	proxy, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if proxy.Scheme == "" || proxy.Host == "" {
+		return nil, fmt.Errorf("expected an absolute URL such as http://proxy.example.com:3128")
+	}
+	return proxy, nil
+}
+
+// parsePositiveDuration parses a duration such as 90s, requiring it to be positive.
+func parsePositiveDuration(raw string) (time.Duration, error) {
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return d, nil
+}
+
 func (p *SevallaProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewApplicationResource,
@@ -127,22 +458,36 @@ func (p *SevallaProvider) Resources(ctx context.Context) []func() resource.Resou
 		NewStaticSiteResource,
 		NewSiteResource,
 		NewPipelineResource,
+		NewApplicationProcessResource,
+		NewDeploymentResource,
+		NewStaticSiteDeploymentResource,
+		NewSiteDomainResource,
+		NewSiteEnvironmentResource,
 	}
 }
 
 func (p *SevallaProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewApplicationDataSource,
+		NewApplicationByNameDataSource,
 		NewDatabaseDataSource,
+		NewDatabaseByNameDataSource,
 		NewStaticSiteDataSource,
 		NewSiteDataSource,
 		NewCompanyUsersDataSource,
 		NewPipelineDataSource,
+		NewOperationDataSource,
+		NewDeploymentDataSource,
+		NewDeploymentStatusDataSource,
+		NewApplicationHTTPRequestsDataSource,
+		NewApplicationBuildTimesDataSource,
 	}
 }
 
 func (p *SevallaProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
-		// No functions for now
+		NewDashboardURLFunction,
+		NewDatabaseURLFunction,
+		NewValidResourceNameFunction,
 	}
 }
@@ -1,11 +1,20 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
 )
 
 const (
@@ -50,3 +59,413 @@ func testAccPreCheck(t *testing.T) {
 func testAccCompanyID() string {
 	return os.Getenv("SEVALLA_COMPANY_ID")
 }
+
+func testProviderConfigure(t *testing.T, p provider.Provider, attributes map[string]tftypes.Value) SevallaProviderData {
+	t.Helper()
+
+	resp := testProviderConfigureResponse(t, p, attributes)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	return resp.ResourceData.(SevallaProviderData)
+}
+
+func testProviderConfigureResponse(t *testing.T, p provider.Provider, attributes map[string]tftypes.Value) provider.ConfigureResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := testNullValues(objectType)
+	// Most tests have no API to validate the token against
+	values["skip_token_validation"] = tftypes.NewValue(tftypes.Bool, true)
+	for name, value := range attributes {
+		values[name] = value
+	}
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}, &resp)
+
+	return resp
+}
+
+func TestProviderConfigureUserAgent(t *testing.T) {
+	server := sevallaapitest.NewServer(t)
+
+	data := testProviderConfigure(t, New("1.2.3")(), map[string]tftypes.Value{
+		"token":             tftypes.NewValue(tftypes.String, "test-token"),
+		"base_url":          tftypes.NewValue(tftypes.String, server.URL),
+		"user_agent_suffix": tftypes.NewValue(tftypes.String, "platform-team"),
+	})
+
+	if _, err := data.Client.Applications.Get(context.Background(), sevallaapitest.ApplicationID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req, _ := server.LastRequest()
+	want := "terraform-provider-sevalla/1.2.3 (terraform-plugin-framework) platform-team"
+	if got := req.Header.Get("User-Agent"); got != want {
+		t.Errorf("expected User-Agent %q, got %q", want, got)
+	}
+}
+
+func TestProviderConfigureExtraHeaders(t *testing.T) {
+	server := sevallaapitest.NewServer(t)
+
+	data := testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+		"token":    tftypes.NewValue(tftypes.String, "test-token"),
+		"base_url": tftypes.NewValue(tftypes.String, server.URL),
+		"extra_headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"X-Org-Id": tftypes.NewValue(tftypes.String, "org-1"),
+		}),
+	})
+
+	if _, err := data.Client.Applications.Get(context.Background(), sevallaapitest.ApplicationID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req, _ := server.LastRequest()
+	if got := req.Header.Get("X-Org-Id"); got != "org-1" {
+		t.Errorf("expected the extra header to be sent, got %q", got)
+	}
+}
+
+func TestProviderConfigureTransport(t *testing.T) {
+	t.Setenv("SEVALLA_PROXY_URL", "")
+
+	t.Run("proxy and insecure", func(t *testing.T) {
+		resp := testProviderConfigureResponse(t, New("test")(), map[string]tftypes.Value{
+			"token":                tftypes.NewValue(tftypes.String, "test-token"),
+			"proxy_url":            tftypes.NewValue(tftypes.String, "http://proxy.example.com:3128"),
+			"insecure_skip_verify": tftypes.NewValue(tftypes.Bool, true),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if resp.Diagnostics.WarningsCount() != 1 {
+			t.Errorf("expected a warning about disabled TLS verification, got %v", resp.Diagnostics)
+		}
+
+		transport := testProviderTransport(t, resp.ResourceData.(SevallaProviderData))
+		req, _ := http.NewRequest(http.MethodGet, "https://api.sevalla.com/v2/applications", nil)
+		proxy, err := transport.Proxy(req)
+		if err != nil || proxy == nil || proxy.Host != "proxy.example.com:3128" {
+			t.Errorf("unexpected proxy %v (%v)", proxy, err)
+		}
+		if transport.TLSClientConfig == nil || !transport.TLSClientConfig.InsecureSkipVerify {
+			t.Error("expected TLS verification to be disabled")
+		}
+	})
+
+	t.Run("proxy from environment", func(t *testing.T) {
+		t.Setenv("SEVALLA_PROXY_URL", "http://env-proxy.example.com:8080")
+
+		data := testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "test-token"),
+		})
+
+		transport := testProviderTransport(t, data)
+		req, _ := http.NewRequest(http.MethodGet, "https://api.sevalla.com/v2/applications", nil)
+		if proxy, _ := transport.Proxy(req); proxy == nil || proxy.Host != "env-proxy.example.com:8080" {
+			t.Errorf("unexpected proxy %v", proxy)
+		}
+		if transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify {
+			t.Error("expected TLS verification to be enabled by default")
+		}
+	})
+
+	t.Run("invalid proxy", func(t *testing.T) {
+		resp := testProviderConfigureResponse(t, New("test")(), map[string]tftypes.Value{
+			"token":     tftypes.NewValue(tftypes.String, "test-token"),
+			"proxy_url": tftypes.NewValue(tftypes.String, "proxy.example.com:3128"),
+		})
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected an invalid proxy URL error")
+		}
+	})
+}
+
+func TestProviderConfigureOperationDeadline(t *testing.T) {
+	t.Setenv("SEVALLA_OPERATION_DEADLINE", "")
+
+	t.Run("unset", func(t *testing.T) {
+		data := testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "test-token"),
+		})
+		if !data.Client.Deadline.IsZero() {
+			t.Errorf("expected no deadline, got %s", data.Client.Deadline)
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		before := time.Now()
+		data := testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+			"token":              tftypes.NewValue(tftypes.String, "test-token"),
+			"operation_deadline": tftypes.NewValue(tftypes.String, "90m"),
+		})
+		if got := data.Client.Deadline.Sub(before); got < 90*time.Minute || got > 91*time.Minute {
+			t.Errorf("expected a deadline 90m from now, got %s", got)
+		}
+	})
+
+	t.Run("from environment", func(t *testing.T) {
+		t.Setenv("SEVALLA_OPERATION_DEADLINE", "1h")
+
+		data := testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "test-token"),
+		})
+		if data.Client.Deadline.IsZero() {
+			t.Error("expected a deadline from SEVALLA_OPERATION_DEADLINE")
+		}
+	})
+
+	for _, value := range []string{"soon", "-5m"} {
+		t.Run("invalid "+value, func(t *testing.T) {
+			resp := testProviderConfigureResponse(t, New("test")(), map[string]tftypes.Value{
+				"token":              tftypes.NewValue(tftypes.String, "test-token"),
+				"operation_deadline": tftypes.NewValue(tftypes.String, value),
+			})
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected an invalid operation deadline error")
+			}
+		})
+	}
+}
+
+func TestProviderConfigureBaseURL(t *testing.T) {
+	t.Setenv("SEVALLA_BASE_URL", "")
+
+	t.Run("trailing slash", func(t *testing.T) {
+		data := testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+			"token":    tftypes.NewValue(tftypes.String, "test-token"),
+			"base_url": tftypes.NewValue(tftypes.String, "https://api.sevalla.com/v2/"),
+		})
+		if data.Client.BaseURL != "https://api.sevalla.com/v2" {
+			t.Errorf("expected the trailing slash to be stripped, got %q", data.Client.BaseURL)
+		}
+	})
+
+	for _, value := range []string{"api.sevalla.com", "ftp://api.sevalla.com", "::not a url::"} {
+		t.Run("invalid "+value, func(t *testing.T) {
+			resp := testProviderConfigureResponse(t, New("test")(), map[string]tftypes.Value{
+				"token":    tftypes.NewValue(tftypes.String, "test-token"),
+				"base_url": tftypes.NewValue(tftypes.String, value),
+			})
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected an invalid base URL error")
+			}
+		})
+	}
+}
+
+func TestProviderConfigureRequestTimeout(t *testing.T) {
+	t.Setenv("SEVALLA_REQUEST_TIMEOUT", "")
+
+	t.Run("unset", func(t *testing.T) {
+		data := testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "test-token"),
+		})
+		if got := data.Client.HTTPClient.Timeout; got != sevallaapi.DefaultTimeout {
+			t.Errorf("expected the default timeout, got %s", got)
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		t.Setenv("SEVALLA_REQUEST_TIMEOUT", "1m")
+
+		data := testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+			"token":           tftypes.NewValue(tftypes.String, "test-token"),
+			"request_timeout": tftypes.NewValue(tftypes.String, "2m"),
+		})
+		if got := data.Client.HTTPClient.Timeout; got != 2*time.Minute {
+			t.Errorf("expected the configured timeout to take precedence, got %s", got)
+		}
+		if got := data.Client.RequestTimeout; got != 2*time.Minute {
+			t.Errorf("expected the configured timeout to bound each request, got %s", got)
+		}
+	})
+
+	t.Run("from environment", func(t *testing.T) {
+		t.Setenv("SEVALLA_REQUEST_TIMEOUT", "90s")
+
+		data := testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "test-token"),
+		})
+		if got := data.Client.HTTPClient.Timeout; got != 90*time.Second {
+			t.Errorf("expected a timeout from SEVALLA_REQUEST_TIMEOUT, got %s", got)
+		}
+	})
+
+	for _, value := range []string{"slow", "0s"} {
+		t.Run("invalid "+value, func(t *testing.T) {
+			resp := testProviderConfigureResponse(t, New("test")(), map[string]tftypes.Value{
+				"token":           tftypes.NewValue(tftypes.String, "test-token"),
+				"request_timeout": tftypes.NewValue(tftypes.String, value),
+			})
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected an invalid request timeout error")
+			}
+		})
+	}
+}
+
+func TestProviderConfigureCompression(t *testing.T) {
+	t.Setenv("SEVALLA_COMPRESSION_ENABLED", "")
+
+	data := testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+		"token": tftypes.NewValue(tftypes.String, "test-token"),
+	})
+	if !data.Client.Compression {
+		t.Error("expected response compression to be enabled by default")
+	}
+
+	t.Setenv("SEVALLA_COMPRESSION_ENABLED", "false")
+	data = testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+		"token": tftypes.NewValue(tftypes.String, "test-token"),
+	})
+	if data.Client.Compression {
+		t.Error("expected SEVALLA_COMPRESSION_ENABLED=false to disable response compression")
+	}
+}
+
+func TestProviderConfigureConnectionPool(t *testing.T) {
+	t.Setenv("SEVALLA_MAX_IDLE_CONNS", "7")
+	t.Setenv("SEVALLA_MAX_OPEN_CONNS", "9")
+	t.Setenv("SEVALLA_CONN_MAX_IDLE_TIME", "2m")
+
+	data := testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+		"token":     tftypes.NewValue(tftypes.String, "test-token"),
+		"proxy_url": tftypes.NewValue(tftypes.String, "http://proxy.example.com:3128"),
+	})
+
+	transport := testProviderTransport(t, data)
+	if transport.MaxIdleConns != 7 || transport.MaxIdleConnsPerHost != 7 {
+		t.Errorf("unexpected idle connection limits %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 9 {
+		t.Errorf("unexpected MaxConnsPerHost %d", transport.MaxConnsPerHost)
+	}
+	if transport.IdleConnTimeout != 2*time.Minute {
+		t.Errorf("unexpected IdleConnTimeout %s", transport.IdleConnTimeout)
+	}
+}
+
+func testProviderTransport(t *testing.T, data SevallaProviderData) *http.Transport {
+	t.Helper()
+
+	transport, ok := data.Client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", data.Client.HTTPClient.Transport)
+	}
+	return transport
+}
+
+func TestProviderConfigureCompanyID(t *testing.T) {
+	t.Setenv("SEVALLA_COMPANY_ID", "env-company")
+
+	t.Run("from environment", func(t *testing.T) {
+		data := testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "test-token"),
+		})
+		if data.CompanyID != "env-company" {
+			t.Errorf("expected the company ID from SEVALLA_COMPANY_ID, got %q", data.CompanyID)
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		data := testProviderConfigure(t, New("test")(), map[string]tftypes.Value{
+			"token":      tftypes.NewValue(tftypes.String, "test-token"),
+			"company_id": tftypes.NewValue(tftypes.String, sevallaapitest.CompanyID),
+		})
+		if data.CompanyID != sevallaapitest.CompanyID {
+			t.Errorf("expected the configured company ID to take precedence, got %q", data.CompanyID)
+		}
+	})
+}
+
+func TestProviderConfigureTokenValidation(t *testing.T) {
+	configure := func(t *testing.T, server *sevallaapitest.Server) provider.ConfigureResponse {
+		t.Helper()
+		return testProviderConfigureResponse(t, New("test")(), map[string]tftypes.Value{
+			"token":                 tftypes.NewValue(tftypes.String, "test-token"),
+			"base_url":              tftypes.NewValue(tftypes.String, server.URL),
+			"skip_token_validation": tftypes.NewValue(tftypes.Bool, nil),
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+
+		resp := configure(t, server)
+		if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 0 {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		req, _ := server.LastRequest()
+		if req.Path != "/validate" {
+			t.Errorf("expected the token to be validated, got request to %s", req.Path)
+		}
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		server.HandleJSON(http.MethodGet, "/validate", http.StatusUnauthorized, `{"message":"Unauthorized","status":401}`)
+
+		resp := configure(t, server)
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Invalid API Token" {
+			t.Errorf("expected an invalid token error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("expires soon", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		expiresAt := time.Now().Add(24 * time.Hour).UnixMilli()
+		server.HandleJSON(http.MethodGet, "/validate", http.StatusOK,
+			fmt.Sprintf(`{"name":"terraform","expires_at":"%d","company":"company-1","status":"active"}`, expiresAt))
+
+		resp := configure(t, server)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "API Token Expires Soon" {
+			t.Errorf("expected a warning about the expiring token, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		server.Close()
+
+		resp := configure(t, server)
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Sevalla API Unreachable" {
+			t.Errorf("expected an unreachable API error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("unexpected response", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		server.HandleJSON(http.MethodGet, "/validate", http.StatusOK, `not json`)
+
+		resp := configure(t, server)
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Unable to Validate Token" {
+			t.Errorf("expected a token validation error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		t.Setenv("SEVALLA_SKIP_TOKEN_VALIDATION", "true")
+
+		resp := configure(t, server)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if len(server.Requests()) != 0 {
+			t.Errorf("expected no API requests, got %d", len(server.Requests()))
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -29,6 +30,13 @@ type SiteDataSourceModel struct {
 	DisplayName types.String `tfsdk:"display_name"`
 	CompanyID   types.String `tfsdk:"company_id"`
 	Status      types.String `tfsdk:"status"`
+	Labels      types.List   `tfsdk:"labels"`
+}
+
+// siteLabelAttrTypes describes the object type of a single site label.
+var siteLabelAttrTypes = map[string]attr.Type{
+	"id":   types.StringType,
+	"name": types.StringType,
 }
 
 func (d *SiteDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -60,6 +68,22 @@ func (d *SiteDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Computed:            true,
 				MarkdownDescription: "The current status of the site.",
 			},
+			"labels": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The labels attached to the site in the Sevalla dashboard. Null if they could not be listed.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The label ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The label name.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -101,5 +125,30 @@ func (d *SiteDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	data.CompanyID = types.StringValue(site.Site.CompanyID)
 	data.Status = types.StringValue(site.Site.Status)
 
+	// Labels are only returned by the list endpoint, so the site is looked up there. They are
+	// left null rather than failing the read if the list cannot be fetched.
+	data.Labels = types.ListNull(types.ObjectType{AttrTypes: siteLabelAttrTypes})
+	sites, err := d.client.Sites.List(ctx, site.Site.CompanyID)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Site Labels Unavailable",
+			fmt.Sprintf("Unable to list sites to read the labels of site %s, so labels is left null, got error: %s", site.Site.ID, err),
+		)
+	} else {
+		labels := []attr.Value{}
+		for _, item := range sites {
+			if item.ID != site.Site.ID {
+				continue
+			}
+			for _, label := range item.SiteLabels {
+				labels = append(labels, types.ObjectValueMust(siteLabelAttrTypes, map[string]attr.Value{
+					"id":   types.StringValue(label.ID),
+					"name": types.StringValue(label.Name),
+				}))
+			}
+		}
+		data.Labels = types.ListValueMust(types.ObjectType{AttrTypes: siteLabelAttrTypes}, labels)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
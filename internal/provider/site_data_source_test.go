@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestSiteDataSourceRead_Labels(t *testing.T) {
+	ctx := context.Background()
+
+	server := sevallaapitest.NewServer(t)
+	d := &SiteDataSource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	read := func() []string {
+		t.Helper()
+		values := testNullValues(objectType)
+		values["id"] = tftypes.NewValue(tftypes.String, sevallaapitest.SiteID)
+
+		resp := &datasource.ReadResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		d.Read(ctx, datasource.ReadRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}, resp)
+
+		var data SiteDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		var labels []struct {
+			ID   string `tfsdk:"id"`
+			Name string `tfsdk:"name"`
+		}
+		resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &labels, false)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if data.Labels.IsNull() {
+			t.Error("expected an empty list rather than null")
+		}
+
+		names := make([]string, len(labels))
+		for i, label := range labels {
+			names[i] = label.ID + ":" + label.Name
+		}
+		return names
+	}
+
+	t.Run("labelled", func(t *testing.T) {
+		if got := read(); len(got) != 1 || got[0] != "label-1:production" {
+			t.Errorf("unexpected labels %v", got)
+		}
+		req, _ := server.LastRequest()
+		if req.Path != "/sites" || req.Query.Get("company") != sevallaapitest.CompanyID {
+			t.Errorf("expected the site's company to be listed, got %s?%s", req.Path, req.Query.Encode())
+		}
+	})
+
+	t.Run("unlabelled", func(t *testing.T) {
+		server.HandleJSON(http.MethodGet, "/sites", http.StatusOK,
+			`{"company": {"sites": [{"id": "site-2", "name": "other", "display_name": "Other", "status": "live", "siteLabels": [{"id": "label-2", "name": "staging"}]}]}}`)
+
+		if got := read(); len(got) != 0 {
+			t.Errorf("expected no labels, got %v", got)
+		}
+	})
+
+	t.Run("list failure", func(t *testing.T) {
+		server.HandleJSON(http.MethodGet, "/sites", http.StatusInternalServerError, `{"message":"Internal error","status":500}`)
+
+		values := testNullValues(objectType)
+		values["id"] = tftypes.NewValue(tftypes.String, sevallaapitest.SiteID)
+		resp := &datasource.ReadResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		d.Read(ctx, datasource.ReadRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}, resp)
+		if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+			t.Fatalf("expected a single warning, got %v", resp.Diagnostics)
+		}
+
+		var data SiteDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		if data.ID.ValueString() != sevallaapitest.SiteID || !data.Labels.IsNull() {
+			t.Errorf("expected the site with null labels, got %s and %s", data.ID, data.Labels)
+		}
+	})
+}
@@ -0,0 +1,335 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SiteDomainResource{}
+var _ resource.ResourceWithImportState = &SiteDomainResource{}
+
+const (
+	defaultSiteDomainCreateTimeout = 10 * time.Minute
+	defaultSiteDomainDeleteTimeout = 10 * time.Minute
+)
+
+func NewSiteDomainResource() resource.Resource {
+	return &SiteDomainResource{}
+}
+
+// SiteDomainResource defines the resource implementation.
+type SiteDomainResource struct {
+	client *sevallaapi.Client
+}
+
+// SiteDomainResourceModel describes the resource data model.
+type SiteDomainResourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	SiteID        types.String   `tfsdk:"site_id"`
+	EnvironmentID types.String   `tfsdk:"environment_id"`
+	Name          types.String   `tfsdk:"name"`
+	Type          types.String   `tfsdk:"type"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *SiteDomainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_site_domain"
+}
+
+func (r *SiteDomainResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Attaches a custom domain to an environment of a Sevalla WordPress site. " +
+			"The primary domain of an environment cannot be removed; make another domain primary first. " +
+			"Can be imported with an identifier of the form `site_id/environment_id/domain_id`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier of the domain.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"site_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the site.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the site environment to attach the domain to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The domain name, for example `www.example.com`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The type of the domain as reported by Sevalla.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *SiteDomainResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(SevallaProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected SevallaProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *SiteDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SiteDomainResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Adding site domain", map[string]interface{}{
+		"site_id":        data.SiteID.ValueString(),
+		"environment_id": data.EnvironmentID.ValueString(),
+		"name":           data.Name.ValueString(),
+	})
+
+	opResp, err := r.client.Sites.AddDomain(ctx, data.EnvironmentID.ValueString(), sevallaapi.AddSiteDomainRequest{
+		DomainName: data.Name.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add site domain, got error: %s", err))
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultSiteDomainCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := waitForOperationCompletion(ctx, r.client, opResp.OperationID, createTimeout); err != nil {
+		resp.Diagnostics.AddError("Operation Error", fmt.Sprintf("Adding the site domain did not complete: %s", err))
+		return
+	}
+
+	// The operation does not report the new domain, so it is looked up by name
+	environment, err := getSiteEnvironment(ctx, r.client, data.SiteID.ValueString(), data.EnvironmentID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read site domain, got error: %s", err))
+		return
+	}
+	domain := findDomainByName(environment.Domains, data.Name.ValueString())
+	if domain == nil {
+		resp.Diagnostics.AddError(
+			"Domain Not Found",
+			fmt.Sprintf("The domain %q was added but is not listed on environment %s.",
+				data.Name.ValueString(), data.EnvironmentID.ValueString()),
+		)
+		return
+	}
+
+	mapSiteDomainToModel(&data, domain)
+
+	tflog.Trace(ctx, "Created site domain resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SiteDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SiteDomainResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environment, err := getSiteEnvironment(ctx, r.client, data.SiteID.ValueString(), data.EnvironmentID.ValueString())
+	var apiErr *sevallaapi.APIError
+	if errors.Is(err, errEnvironmentNotFound) || (errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read site domain, got error: %s", err))
+		return
+	}
+
+	domain := findDomainByID(environment.Domains, data.ID.ValueString())
+	if domain == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	mapSiteDomainToModel(&data, domain)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SiteDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SiteDomainResourceModel
+
+	// Every other argument forces replacement, so only the timeouts can change in place
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SiteDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SiteDomainResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environment, err := getSiteEnvironment(ctx, r.client, data.SiteID.ValueString(), data.EnvironmentID.ValueString())
+	var apiErr *sevallaapi.APIError
+	if errors.Is(err, errEnvironmentNotFound) || (errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) {
+		// The environment, and every domain on it, is already gone
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read site domain, got error: %s", err))
+		return
+	}
+
+	if environment.PrimaryDomain.ID == data.ID.ValueString() {
+		resp.Diagnostics.AddError(
+			"Primary Domain Cannot Be Deleted",
+			fmt.Sprintf("The domain %q is the primary domain of environment %s. Make another domain primary "+
+				"in the Sevalla dashboard before removing this one, or remove it from state with terraform state rm.",
+				data.Name.ValueString(), data.EnvironmentID.ValueString()),
+		)
+		return
+	}
+	if findDomainByID(environment.Domains, data.ID.ValueString()) == nil {
+		return
+	}
+
+	opResp, err := r.client.Sites.DeleteDomains(ctx, data.EnvironmentID.ValueString(), []string{data.ID.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete site domain, got error: %s", err))
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultSiteDomainDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := waitForOperationCompletion(ctx, r.client, opResp.OperationID, deleteTimeout); err != nil {
+		resp.Diagnostics.AddError("Operation Error", fmt.Sprintf("Site domain deletion did not complete: %s", err))
+		return
+	}
+}
+
+func (r *SiteDomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: site_id/environment_id/domain_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[2])...)
+}
+
+// errEnvironmentNotFound is returned by getSiteEnvironment when the site has no such environment.
+var errEnvironmentNotFound = errors.New("environment not found")
+
+// getSiteEnvironment reads the site and returns the environment with the given ID.
+func getSiteEnvironment(ctx context.Context, client *sevallaapi.Client, siteID, environmentID string) (*sevallaapi.Environment, error) {
+	site, err := client.Sites.Get(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range site.Site.Environments {
+		if site.Site.Environments[i].ID == environmentID {
+			return &site.Site.Environments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: site %s has no environment %s", errEnvironmentNotFound, siteID, environmentID)
+}
+
+func findDomainByID(domains []sevallaapi.Domain, id string) *sevallaapi.Domain {
+	for i := range domains {
+		if domains[i].ID == id {
+			return &domains[i]
+		}
+	}
+	return nil
+}
+
+// findDomainByName matches domain names case-insensitively, as DNS names are.
+func findDomainByName(domains []sevallaapi.Domain, name string) *sevallaapi.Domain {
+	for i := range domains {
+		if strings.EqualFold(domains[i].Name, name) {
+			return &domains[i]
+		}
+	}
+	return nil
+}
+
+func mapSiteDomainToModel(data *SiteDomainResourceModel, domain *sevallaapi.Domain) {
+	data.ID = types.StringValue(domain.ID)
+	// Keep the configured spelling of the name if only its case differs
+	if !strings.EqualFold(data.Name.ValueString(), domain.Name) {
+		data.Name = types.StringValue(domain.Name)
+	}
+	data.Type = types.StringValue(domain.Type)
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+// siteWithCustomDomain is the site fixture with www.example.com added to its live environment.
+var siteWithCustomDomain = strings.Replace(sevallaapitest.SiteFixture,
+	`"domains": [{"id": "domain-1", "name": "my-wp-site.kinsta.cloud", "type": "live"}]`,
+	`"domains": [{"id": "domain-1", "name": "my-wp-site.kinsta.cloud", "type": "live"}, {"id": "domain-2", "name": "www.example.com", "type": "custom"}]`, 1)
+
+func testSiteDomainResource(t *testing.T) (*SiteDomainResource, *sevallaapitest.Server, fwresource.SchemaResponse, tftypes.Object) {
+	t.Helper()
+	ctx := context.Background()
+
+	r := &SiteDomainResource{}
+	_, server := testSiteResource(t)
+	r.client = sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	return r, server, schemaResp, objectType
+}
+
+func testSiteDomainValue(objectType tftypes.Object, id, name string) tftypes.Value {
+	values := testNullValues(objectType)
+	values["site_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.SiteID)
+	values["environment_id"] = tftypes.NewValue(tftypes.String, "env-1")
+	values["name"] = tftypes.NewValue(tftypes.String, name)
+	if id == "" {
+		values["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+		values["type"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	} else {
+		values["id"] = tftypes.NewValue(tftypes.String, id)
+	}
+	return tftypes.NewValue(objectType, values)
+}
+
+func TestSiteDomainResourceCreate(t *testing.T) {
+	ctx := context.Background()
+	r, server, schemaResp, objectType := testSiteDomainResource(t)
+	server.HandleJSON(http.MethodGet, "/sites/{id}", http.StatusOK, siteWithCustomDomain)
+
+	resp := &fwresource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: testSiteDomainValue(objectType, "", "WWW.example.com")},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data SiteDomainResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if data.ID.ValueString() != "domain-2" || data.Type.ValueString() != "custom" {
+		t.Errorf("expected domain-2 of type custom, got %s of type %s", data.ID, data.Type)
+	}
+	if data.Name.ValueString() != "WWW.example.com" {
+		t.Errorf("expected the configured name to be kept, got %s", data.Name)
+	}
+
+	requests := server.Requests()
+	if len(requests) == 0 || requests[0].Method != http.MethodPost || requests[0].Path != "/sites/environments/env-1/domains" {
+		t.Fatalf("expected the domain to be added to env-1, got %+v", requests)
+	}
+	if !strings.Contains(string(requests[0].Body), `"domain_name":"WWW.example.com"`) {
+		t.Errorf("unexpected add domain body %s", requests[0].Body)
+	}
+}
+
+func TestSiteDomainResourceRead_Removed(t *testing.T) {
+	ctx := context.Background()
+	r, _, schemaResp, objectType := testSiteDomainResource(t)
+
+	resp := &fwresource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: testSiteDomainValue(objectType, "domain-2", "www.example.com")},
+	}
+	r.Read(ctx, fwresource.ReadRequest{State: resp.State}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected a domain that is no longer listed to be removed from state")
+	}
+}
+
+func TestSiteDomainResourceDelete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("custom domain", func(t *testing.T) {
+		r, server, schemaResp, objectType := testSiteDomainResource(t)
+		server.HandleJSON(http.MethodGet, "/sites/{id}", http.StatusOK, siteWithCustomDomain)
+
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: testSiteDomainValue(objectType, "domain-2", "www.example.com")}
+		resp := &fwresource.DeleteResponse{State: state}
+		r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var deleted *sevallaapitest.Request
+		for _, req := range server.Requests() {
+			if req.Method == http.MethodDelete {
+				deleted = &req
+			}
+		}
+		if deleted == nil || deleted.Path != "/sites/environments/env-1/domains" || !strings.Contains(string(deleted.Body), `"domain_ids":["domain-2"]`) {
+			t.Errorf("expected domain-2 to be deleted from env-1, got %+v", deleted)
+		}
+	})
+
+	t.Run("primary domain", func(t *testing.T) {
+		r, server, schemaResp, objectType := testSiteDomainResource(t)
+
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: testSiteDomainValue(objectType, "domain-1", "my-wp-site.kinsta.cloud")}
+		resp := &fwresource.DeleteResponse{State: state}
+		r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Primary Domain Cannot Be Deleted" {
+			t.Errorf("expected a primary domain error, got %v", resp.Diagnostics)
+		}
+		for _, req := range server.Requests() {
+			if req.Method == http.MethodDelete {
+				t.Errorf("expected no delete request, got %s %s", req.Method, req.Path)
+			}
+		}
+	})
+}
+
+func TestSiteDomainResourceImportState(t *testing.T) {
+	ctx := context.Background()
+	r, _, schemaResp, objectType := testSiteDomainResource(t)
+
+	importState := func(id string) (SiteDomainResourceModel, *fwresource.ImportStateResponse) {
+		resp := &fwresource.ImportStateResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		r.ImportState(ctx, fwresource.ImportStateRequest{ID: id}, resp)
+
+		var data SiteDomainResourceModel
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		}
+		return data, resp
+	}
+
+	data, resp := importState(sevallaapitest.SiteID + "/env-1/domain-2")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if data.SiteID.ValueString() != sevallaapitest.SiteID || data.EnvironmentID.ValueString() != "env-1" || data.ID.ValueString() != "domain-2" {
+		t.Errorf("unexpected imported identifiers %s, %s, %s", data.SiteID, data.EnvironmentID, data.ID)
+	}
+
+	for _, id := range []string{"domain-2", "site-1/env-1", "site-1//domain-2"} {
+		if _, resp := importState(id); !resp.Diagnostics.HasError() {
+			t.Errorf("expected an error for import identifier %q", id)
+		}
+	}
+}
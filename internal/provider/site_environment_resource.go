@@ -0,0 +1,416 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SiteEnvironmentResource{}
+var _ resource.ResourceWithImportState = &SiteEnvironmentResource{}
+var _ resource.ResourceWithValidateConfig = &SiteEnvironmentResource{}
+
+const (
+	defaultSiteEnvironmentCreateTimeout = 30 * time.Minute
+	defaultSiteEnvironmentUpdateTimeout = 30 * time.Minute
+	defaultSiteEnvironmentDeleteTimeout = 10 * time.Minute
+)
+
+// liveEnvironmentName is the name of the environment that serves a site's production traffic.
+const liveEnvironmentName = "live"
+
+func NewSiteEnvironmentResource() resource.Resource {
+	return &SiteEnvironmentResource{}
+}
+
+// SiteEnvironmentResource defines the resource implementation.
+type SiteEnvironmentResource struct {
+	client *sevallaapi.Client
+}
+
+// SiteEnvironmentResourceModel describes the resource data model.
+type SiteEnvironmentResourceModel struct {
+	ID                     types.String   `tfsdk:"id"`
+	SiteID                 types.String   `tfsdk:"site_id"`
+	DisplayName            types.String   `tfsdk:"display_name"`
+	CloneFromEnvironmentID types.String   `tfsdk:"clone_from_environment_id"`
+	IsPremium              types.Bool     `tfsdk:"is_premium"`
+	PromoteTrigger         types.String   `tfsdk:"promote_trigger"`
+	AllowPromote           types.Bool     `tfsdk:"allow_promote"`
+	Name                   types.String   `tfsdk:"name"`
+	PrimaryDomain          types.String   `tfsdk:"primary_domain"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *SiteEnvironmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_site_environment"
+}
+
+func (r *SiteEnvironmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds an environment, such as staging, to a Sevalla WordPress site. " +
+			"The environment is a copy of `clone_from_environment_id` when set, and is created without WordPress installed otherwise. " +
+			"Can be imported with an identifier of the form `site_id/environment_id`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier of the environment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"site_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the site.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The display name of the environment, for example `staging`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"clone_from_environment_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of an environment of the same site to clone, usually the live one. Only used when the environment is created.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"is_premium": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the environment is a premium staging environment. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"promote_trigger": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Any value; changing it promotes this environment to production by pushing its files and " +
+					"database onto the site's live environment. Setting it when the environment is created does not promote. " +
+					"**Promotion overwrites the live site's content and database: changes made on the live environment since " +
+					"it was cloned, such as new orders, comments or users, are lost and can only be recovered from a backup.** " +
+					"Requires `allow_promote`.",
+			},
+			"allow_promote": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Must be `true` for `promote_trigger` to be set, as a guard against promoting by accident. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the environment as generated by Sevalla.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"primary_domain": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The primary domain of the environment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *SiteEnvironmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(SevallaProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected SevallaProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *SiteEnvironmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SiteEnvironmentResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values are validated again once they are known
+	if data.PromoteTrigger.IsNull() || data.AllowPromote.IsUnknown() || data.AllowPromote.ValueBool() {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(
+		path.Root("promote_trigger"),
+		"Promotion Not Allowed",
+		"Promoting an environment overwrites the live environment's files and database. "+
+			"Set allow_promote = true to confirm that changes made on the live environment may be lost.",
+	)
+}
+
+func (r *SiteEnvironmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SiteEnvironmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating site environment", map[string]interface{}{
+		"site_id":                   data.SiteID.ValueString(),
+		"display_name":              data.DisplayName.ValueString(),
+		"clone_from_environment_id": data.CloneFromEnvironmentID.ValueString(),
+	})
+
+	opResp, err := r.client.Sites.CreateEnvironment(ctx, data.SiteID.ValueString(), sevallaapi.CreateEnvironmentRequest{
+		DisplayName:            data.DisplayName.ValueString(),
+		IsPremium:              data.IsPremium.ValueBool(),
+		CloneFromEnvironmentID: data.CloneFromEnvironmentID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create site environment, got error: %s", err))
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultSiteEnvironmentCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	op, err := waitForOperationCompletion(ctx, r.client, opResp.OperationID, createTimeout)
+	if err != nil {
+		resp.Diagnostics.AddError("Operation Error", fmt.Sprintf("Site environment creation did not complete: %s", err))
+		return
+	}
+
+	site, err := r.client.Sites.Get(ctx, data.SiteID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read site environment, got error: %s", err))
+		return
+	}
+	environment := findNewEnvironment(site.Site.Environments, op.ResourceID, data.DisplayName.ValueString())
+	if environment == nil {
+		resp.Diagnostics.AddError(
+			"Environment Not Found",
+			fmt.Sprintf("The environment %q was created but is not listed on site %s.",
+				data.DisplayName.ValueString(), data.SiteID.ValueString()),
+		)
+		return
+	}
+
+	mapSiteEnvironmentToModel(&data, environment)
+
+	tflog.Trace(ctx, "Created site environment resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SiteEnvironmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SiteEnvironmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environment, err := getSiteEnvironment(ctx, r.client, data.SiteID.ValueString(), data.ID.ValueString())
+	var apiErr *sevallaapi.APIError
+	if errors.Is(err, errEnvironmentNotFound) || (errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read site environment, got error: %s", err))
+		return
+	}
+
+	mapSiteEnvironmentToModel(&data, environment)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SiteEnvironmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state SiteEnvironmentResourceModel
+
+	// Every other argument forces replacement, so only the promotion settings and timeouts change in place
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.PromoteTrigger.IsNull() && !data.PromoteTrigger.Equal(state.PromoteTrigger) {
+		if !data.AllowPromote.ValueBool() {
+			resp.Diagnostics.AddAttributeError(path.Root("allow_promote"), "Promotion Not Allowed",
+				"Set allow_promote = true to promote the environment.")
+			return
+		}
+
+		updateTimeout, diags := data.Timeouts.Update(ctx, defaultSiteEnvironmentUpdateTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if err := r.promote(ctx, data.SiteID.ValueString(), data.ID.ValueString(), updateTimeout); err != nil {
+			resp.Diagnostics.AddError("Promotion Error", fmt.Sprintf("Unable to promote site environment: %s", err))
+			return
+		}
+		resp.Diagnostics.AddWarning(
+			"Environment Promoted",
+			fmt.Sprintf("Environment %s was pushed onto the live environment of site %s, replacing its files and database.",
+				data.ID.ValueString(), data.SiteID.ValueString()),
+		)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// promote pushes the environment onto the site's live environment and waits for the push to finish.
+func (r *SiteEnvironmentResource) promote(ctx context.Context, siteID, environmentID string, timeout time.Duration) error {
+	site, err := r.client.Sites.Get(ctx, siteID)
+	if err != nil {
+		return fmt.Errorf("failed to read site: %w", err)
+	}
+
+	var live *sevallaapi.Environment
+	for i := range site.Site.Environments {
+		if site.Site.Environments[i].Name == liveEnvironmentName {
+			live = &site.Site.Environments[i]
+		}
+	}
+	switch {
+	case live == nil:
+		return fmt.Errorf("site %s has no %s environment", siteID, liveEnvironmentName)
+	case live.ID == environmentID:
+		return fmt.Errorf("environment %s is already the live environment", environmentID)
+	}
+
+	tflog.Debug(ctx, "Promoting site environment", map[string]interface{}{
+		"site_id":        siteID,
+		"environment_id": environmentID,
+		"target_env_id":  live.ID,
+	})
+
+	opResp, err := r.client.Sites.PromoteEnvironment(ctx, siteID, environmentID, live.ID)
+	if err != nil {
+		return err
+	}
+	_, err = waitForOperationCompletion(ctx, r.client, opResp.OperationID, timeout)
+	return err
+}
+
+func (r *SiteEnvironmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SiteEnvironmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opResp, err := r.client.Sites.DeleteEnvironment(ctx, data.ID.ValueString())
+	var apiErr *sevallaapi.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete site environment, got error: %s", err))
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultSiteEnvironmentDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := waitForOperationCompletion(ctx, r.client, opResp.OperationID, deleteTimeout); err != nil {
+		resp.Diagnostics.AddError("Operation Error", fmt.Sprintf("Site environment deletion did not complete: %s", err))
+		return
+	}
+}
+
+func (r *SiteEnvironmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: site_id/environment_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("allow_promote"), false)...)
+}
+
+// findNewEnvironment returns the environment an operation created. Operations do not always
+// report the environment's ID, so it is otherwise looked up by display name.
+func findNewEnvironment(environments []sevallaapi.Environment, id, displayName string) *sevallaapi.Environment {
+	if id != "" {
+		for i := range environments {
+			if environments[i].ID == id {
+				return &environments[i]
+			}
+		}
+	}
+	for i := range environments {
+		if environments[i].DisplayName == displayName {
+			return &environments[i]
+		}
+	}
+	return nil
+}
+
+func mapSiteEnvironmentToModel(data *SiteEnvironmentResourceModel, environment *sevallaapi.Environment) {
+	data.ID = types.StringValue(environment.ID)
+	data.DisplayName = types.StringValue(environment.DisplayName)
+	data.IsPremium = types.BoolValue(environment.IsPremium)
+	data.Name = types.StringValue(environment.Name)
+	data.PrimaryDomain = types.StringValue(environment.PrimaryDomain.Name)
+}
@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+// siteWithStaging is the site fixture with a staging environment added after its live one.
+var siteWithStaging = strings.Replace(sevallaapitest.SiteFixture, `
+    ]
+  }
+}`, `,
+      {
+        "id": "env-2",
+        "name": "staging",
+        "display_name": "staging",
+        "is_premium": false,
+        "is_blocked": false,
+        "domains": [{"id": "domain-3", "name": "staging-my-wp-site.kinsta.cloud", "type": "staging"}],
+        "primaryDomain": {"id": "domain-3", "name": "staging-my-wp-site.kinsta.cloud", "type": "staging"}
+      }
+    ]
+  }
+}`, 1)
+
+func testSiteEnvironmentResource(t *testing.T) (*SiteEnvironmentResource, *sevallaapitest.Server, fwresource.SchemaResponse, tftypes.Object) {
+	t.Helper()
+	ctx := context.Background()
+
+	r := &SiteEnvironmentResource{}
+	_, server := testSiteResource(t)
+	r.client = sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	return r, server, schemaResp, objectType
+}
+
+func testSiteEnvironmentValue(objectType tftypes.Object, id, cloneFrom string) tftypes.Value {
+	values := testNullValues(objectType)
+	values["site_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.SiteID)
+	values["display_name"] = tftypes.NewValue(tftypes.String, "staging")
+	values["is_premium"] = tftypes.NewValue(tftypes.Bool, false)
+	if cloneFrom != "" {
+		values["clone_from_environment_id"] = tftypes.NewValue(tftypes.String, cloneFrom)
+	}
+	if id == "" {
+		values["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+		values["name"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+		values["primary_domain"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	} else {
+		values["id"] = tftypes.NewValue(tftypes.String, id)
+	}
+	return tftypes.NewValue(objectType, values)
+}
+
+func TestSiteEnvironmentResourceCreate(t *testing.T) {
+	ctx := context.Background()
+
+	for name, tc := range map[string]struct {
+		cloneFrom string
+		path      string
+	}{
+		"clone": {cloneFrom: "env-1", path: "/sites/site-1/environments/clone"},
+		"plain": {path: "/sites/site-1/environments/plain"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r, server, schemaResp, objectType := testSiteEnvironmentResource(t)
+			server.HandleJSON(http.MethodGet, "/sites/{id}", http.StatusOK, siteWithStaging)
+
+			resp := &fwresource.CreateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+			r.Create(ctx, fwresource.CreateRequest{
+				Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: testSiteEnvironmentValue(objectType, "", tc.cloneFrom)},
+			}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data SiteEnvironmentResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if data.ID.ValueString() != "env-2" || data.Name.ValueString() != "staging" {
+				t.Errorf("expected env-2 named staging, got %s named %s", data.ID, data.Name)
+			}
+			if data.PrimaryDomain.ValueString() != "staging-my-wp-site.kinsta.cloud" {
+				t.Errorf("unexpected primary domain %s", data.PrimaryDomain)
+			}
+
+			requests := server.Requests()
+			if len(requests) == 0 || requests[0].Method != http.MethodPost || requests[0].Path != tc.path {
+				t.Fatalf("expected the environment to be created with %s, got %+v", tc.path, requests)
+			}
+			if tc.cloneFrom != "" && !strings.Contains(string(requests[0].Body), `"source_env_id":"env-1"`) {
+				t.Errorf("unexpected clone body %s", requests[0].Body)
+			}
+		})
+	}
+}
+
+func TestSiteEnvironmentResourceCreate_NotListed(t *testing.T) {
+	ctx := context.Background()
+	r, _, schemaResp, objectType := testSiteEnvironmentResource(t)
+
+	resp := &fwresource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: testSiteEnvironmentValue(objectType, "", "")},
+	}, resp)
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Environment Not Found" {
+		t.Errorf("expected an environment not found error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestSiteEnvironmentResourceRead_Removed(t *testing.T) {
+	ctx := context.Background()
+	r, _, schemaResp, objectType := testSiteEnvironmentResource(t)
+
+	resp := &fwresource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: testSiteEnvironmentValue(objectType, "env-2", "")},
+	}
+	r.Read(ctx, fwresource.ReadRequest{State: resp.State}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected an environment that is no longer listed to be removed from state")
+	}
+}
+
+func TestSiteEnvironmentResourceDelete(t *testing.T) {
+	ctx := context.Background()
+	r, server, schemaResp, objectType := testSiteEnvironmentResource(t)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: testSiteEnvironmentValue(objectType, "env-2", "")}
+	resp := &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	requests := server.Requests()
+	if len(requests) == 0 || requests[0].Method != http.MethodDelete || requests[0].Path != "/sites/environments/env-2" {
+		t.Errorf("expected env-2 to be deleted, got %+v", requests)
+	}
+}
+
+func TestSiteEnvironmentResourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r, _, schemaResp, objectType := testSiteEnvironmentResource(t)
+
+	for name, tc := range map[string]struct {
+		trigger      tftypes.Value
+		allowPromote tftypes.Value
+		wantError    bool
+	}{
+		"no trigger":          {trigger: tftypes.NewValue(tftypes.String, nil), allowPromote: tftypes.NewValue(tftypes.Bool, nil)},
+		"trigger not allowed": {trigger: tftypes.NewValue(tftypes.String, "v1"), allowPromote: tftypes.NewValue(tftypes.Bool, nil), wantError: true},
+		"trigger disallowed":  {trigger: tftypes.NewValue(tftypes.String, "v1"), allowPromote: tftypes.NewValue(tftypes.Bool, false), wantError: true},
+		"trigger allowed":     {trigger: tftypes.NewValue(tftypes.String, "v1"), allowPromote: tftypes.NewValue(tftypes.Bool, true)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			values := testNullValues(objectType)
+			values["site_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.SiteID)
+			values["display_name"] = tftypes.NewValue(tftypes.String, "staging")
+			values["promote_trigger"] = tc.trigger
+			values["allow_promote"] = tc.allowPromote
+
+			resp := &fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+			}, resp)
+			if resp.Diagnostics.HasError() != tc.wantError {
+				t.Errorf("expected error %t, got %v", tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestSiteEnvironmentResourceUpdate_Promote(t *testing.T) {
+	ctx := context.Background()
+
+	withPromotion := func(value tftypes.Value, trigger string) tftypes.Value {
+		var values map[string]tftypes.Value
+		if err := value.As(&values); err != nil {
+			t.Fatal(err)
+		}
+		values["allow_promote"] = tftypes.NewValue(tftypes.Bool, true)
+		if trigger != "" {
+			values["promote_trigger"] = tftypes.NewValue(tftypes.String, trigger)
+		}
+		return tftypes.NewValue(value.Type(), values)
+	}
+
+	update := func(t *testing.T, stateTrigger, planTrigger string) (*sevallaapitest.Server, *fwresource.UpdateResponse) {
+		r, server, schemaResp, objectType := testSiteEnvironmentResource(t)
+		server.HandleJSON(http.MethodGet, "/sites/{id}", http.StatusOK, siteWithStaging)
+
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: withPromotion(testSiteEnvironmentValue(objectType, "env-2", ""), stateTrigger)}
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: withPromotion(testSiteEnvironmentValue(objectType, "env-2", ""), planTrigger)}
+		resp := &fwresource.UpdateResponse{State: state}
+		r.Update(ctx, fwresource.UpdateRequest{State: state, Plan: plan}, resp)
+		return server, resp
+	}
+
+	t.Run("trigger changed", func(t *testing.T) {
+		server, resp := update(t, "v1", "v2")
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "Environment Promoted" {
+			t.Errorf("expected a promotion warning, got %v", resp.Diagnostics)
+		}
+
+		var pushed *sevallaapitest.Request
+		for _, req := range server.Requests() {
+			if req.Method == http.MethodPut {
+				pushed = &req
+			}
+		}
+		if pushed == nil || !strings.Contains(string(pushed.Body), `"source_env_id":"env-2","target_env_id":"env-1"`) {
+			t.Errorf("expected env-2 to be pushed onto env-1, got %+v", pushed)
+		}
+	})
+
+	t.Run("trigger unchanged", func(t *testing.T) {
+		server, resp := update(t, "v1", "v1")
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if len(server.Requests()) != 0 {
+			t.Errorf("expected no requests, got %+v", server.Requests())
+		}
+	})
+}
+
+func TestSiteEnvironmentResourceImportState(t *testing.T) {
+	ctx := context.Background()
+	r, _, schemaResp, objectType := testSiteEnvironmentResource(t)
+
+	for id, wantError := range map[string]bool{
+		"site-1/env-2": false,
+		"env-2":        true,
+		"site-1/":      true,
+	} {
+		resp := &fwresource.ImportStateResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+		}
+		r.ImportState(ctx, fwresource.ImportStateRequest{ID: id}, resp)
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("import of %q: expected error %t, got %v", id, wantError, resp.Diagnostics)
+			continue
+		}
+		if wantError {
+			continue
+		}
+
+		var data SiteEnvironmentResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		if data.SiteID.ValueString() != "site-1" || data.ID.ValueString() != "env-2" {
+			t.Errorf("unexpected imported state %s/%s", data.SiteID, data.ID)
+		}
+	}
+}
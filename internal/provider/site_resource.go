@@ -3,14 +3,19 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
@@ -19,6 +24,34 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SiteResource{}
 var _ resource.ResourceWithImportState = &SiteResource{}
+var _ resource.ResourceWithValidateConfig = &SiteResource{}
+
+const (
+	defaultSiteCreateTimeout = 10 * time.Minute
+	defaultSiteDeleteTimeout = 10 * time.Minute
+)
+
+// Patterns for the WordPress installation settings of new sites.
+var (
+	emailPattern      = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	wpLanguagePattern = regexp.MustCompile(`^[a-z]{2,3}(_[A-Z]{2})?(_[a-z]+)?$`)
+)
+
+// requiresReplaceIfCreatedWith replaces the site when a setting it was created with changes. The
+// settings are not read back from the API, so an imported site has none in state; setting them
+// afterwards only records them.
+func requiresReplaceIfCreatedWith() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !req.StateValue.IsNull()
+		},
+		"Changing this value after the site is created forces a new site.",
+		"Changing this value after the site is created forces a new site.",
+	)
+}
+
+// operationPollInterval is how often operation and site status is polled. Tests shorten it.
+var operationPollInterval = 5 * time.Second
 
 func NewSiteResource() resource.Resource {
 	return &SiteResource{}
@@ -26,7 +59,8 @@ func NewSiteResource() resource.Resource {
 
 // SiteResource defines the resource implementation.
 type SiteResource struct {
-	client *sevallaapi.Client
+	client    *sevallaapi.Client
+	companyID string
 }
 
 // DomainModel represents a domain attached to an environment.
@@ -49,12 +83,25 @@ type EnvironmentModel struct {
 
 // SiteResourceModel describes the resource data model.
 type SiteResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	DisplayName  types.String `tfsdk:"display_name"`
-	CompanyID    types.String `tfsdk:"company_id"`
-	Status       types.String `tfsdk:"status"`
-	Environments types.List   `tfsdk:"environments"`
+	ID           types.String   `tfsdk:"id"`
+	Name         types.String   `tfsdk:"name"`
+	DisplayName  types.String   `tfsdk:"display_name"`
+	CompanyID    types.String   `tfsdk:"company_id"`
+	Status       types.String   `tfsdk:"status"`
+	Environments types.List     `tfsdk:"environments"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+
+	// Creation settings. The API does not return them, so they are kept from the configuration.
+	Region               types.String `tfsdk:"region"`
+	SiteTitle            types.String `tfsdk:"site_title"`
+	AdminUser            types.String `tfsdk:"admin_user"`
+	AdminEmail           types.String `tfsdk:"admin_email"`
+	AdminPassword        types.String `tfsdk:"admin_password"`
+	WPLanguage           types.String `tfsdk:"wp_language"`
+	IsMultisite          types.Bool   `tfsdk:"is_multisite"`
+	IsSubdomainMultisite types.Bool   `tfsdk:"is_subdomain_multisite"`
+	WooCommerce          types.Bool   `tfsdk:"woocommerce"`
+	WordPressSEO         types.Bool   `tfsdk:"wordpressseo"`
 }
 
 func (r *SiteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -82,13 +129,106 @@ func (r *SiteResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "The display name of the site.",
 			},
 			"company_id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The company ID that owns this site.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The company ID that owns this site. Defaults to the provider's `company_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The current status of the site.",
 			},
+			"region": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The data center region for the site, e.g. `us-central1`. See the [available regions](https://kinsta.com/docs/data-center-locations/). Changing this forces a new site.",
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfCreatedWith(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"site_title": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The title of the WordPress site. Changing this forces a new site.",
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfCreatedWith(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"admin_user": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The username of the initial WordPress administrator. Changing this forces a new site.",
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfCreatedWith(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"admin_email": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The email address of the initial WordPress administrator. Changing this forces a new site.",
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfCreatedWith(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(emailPattern, "must be an email address"),
+				},
+			},
+			"admin_password": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The password of the initial WordPress administrator. Changing this forces a new site.",
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfCreatedWith(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"wp_language": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The WordPress locale, e.g. `en_US`. Changing this forces a new site.",
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfCreatedWith(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(wpLanguagePattern, "must be a WordPress locale such as en_US"),
+				},
+			},
+			"is_multisite": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to install WordPress as a multisite network. Changing this forces a new site.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"is_subdomain_multisite": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether the multisite network uses subdomains instead of subdirectories. Requires `is_multisite`. Changing this forces a new site.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"woocommerce": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to install WooCommerce. Changing this forces a new site.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"wordpressseo": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to install Yoast SEO. Changing this forces a new site.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
 			"environments": schema.ListNestedAttribute{
 				Computed:            true,
 				MarkdownDescription: "List of environments for this WordPress site.",
@@ -156,6 +296,30 @@ func (r *SiteResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *SiteResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SiteResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.IsSubdomainMultisite.ValueBool() && !data.IsMultisite.IsUnknown() && !data.IsMultisite.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("is_subdomain_multisite"),
+			"Invalid Multisite Configuration",
+			"is_subdomain_multisite can only be set when is_multisite is true.",
+		)
 	}
 }
 
@@ -174,6 +338,7 @@ func (r *SiteResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	}
 
 	r.client = data.Client
+	r.companyID = data.CompanyID
 }
 
 func (r *SiteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -184,14 +349,31 @@ func (r *SiteResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	data.CompanyID = companyIDOrDefault(data.CompanyID, r.companyID)
+	if data.CompanyID.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("company_id"), "Missing Company ID", missingCompanyIDDetail)
+		return
+	}
+
 	createReq := sevallaapi.CreateSiteRequest{
-		CompanyID:   data.CompanyID.ValueString(),
-		DisplayName: data.DisplayName.ValueString(),
+		CompanyID:            data.CompanyID.ValueString(),
+		DisplayName:          data.DisplayName.ValueString(),
+		Region:               data.Region.ValueString(),
+		SiteTitle:            data.SiteTitle.ValueString(),
+		AdminUser:            data.AdminUser.ValueString(),
+		AdminEmail:           data.AdminEmail.ValueString(),
+		AdminPassword:        data.AdminPassword.ValueString(),
+		WPLanguage:           data.WPLanguage.ValueString(),
+		IsMultisite:          data.IsMultisite.ValueBool(),
+		IsSubdomainMultisite: data.IsSubdomainMultisite.ValueBool(),
+		WooCommerce:          data.WooCommerce.ValueBool(),
+		WordPressSEO:         data.WordPressSEO.ValueBool(),
 	}
 
 	tflog.Debug(ctx, "Creating site", map[string]interface{}{
 		"company_id":   createReq.CompanyID,
 		"display_name": createReq.DisplayName,
+		"region":       createReq.Region,
 	})
 
 	opResp, err := r.client.Sites.Create(ctx, createReq)
@@ -200,8 +382,14 @@ func (r *SiteResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultSiteCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Wait for the operation to complete
-	siteID, err := r.waitForOperation(ctx, opResp.OperationID)
+	siteID, err := r.waitForOperation(ctx, opResp.OperationID, createTimeout)
 	if err != nil {
 		resp.Diagnostics.AddError("Operation Error", fmt.Sprintf("Site creation operation failed: %s", err))
 		return
@@ -250,6 +438,8 @@ func (r *SiteResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	data.CompanyID = companyIDOrDefault(data.CompanyID, r.companyID)
+
 	updateReq := sevallaapi.UpdateSiteRequest{
 		DisplayName: stringPointer(data.DisplayName.ValueString()),
 	}
@@ -274,11 +464,31 @@ func (r *SiteResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.client.Sites.Delete(ctx, data.ID.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultSiteDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := deleteRetryingConflicts(ctx, deleteConflictRetryInterval, deleteTimeout, func(ctx context.Context) error {
+		return r.client.Sites.Delete(ctx, data.ID.ValueString())
+	})
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete site, got error: %s", err))
 		return
 	}
+
+	deleteCtx, cancel := r.client.WithDeadline(ctx)
+	defer cancel()
+
+	err = waitForDeletion(deleteCtx, operationPollInterval, deleteTimeout, func(ctx context.Context) error {
+		_, err := r.client.Sites.Get(ctx, data.ID.ValueString())
+		return err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Operation Error", fmt.Sprintf("Site deletion did not complete: %s", err))
+		return
+	}
 }
 
 func (r *SiteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -286,46 +496,25 @@ func (r *SiteResource) ImportState(ctx context.Context, req resource.ImportState
 }
 
 // waitForOperation waits for an operation to complete and returns the resource ID
-func (r *SiteResource) waitForOperation(ctx context.Context, operationID string) (string, error) {
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-	timeout := time.After(10 * time.Minute)
-
-	for {
-		select {
-		case <-ticker.C:
-			op, err := r.client.Operations.GetStatus(ctx, operationID)
-			if err != nil {
-				return "", fmt.Errorf("failed to get operation status: %w", err)
-			}
+func (r *SiteResource) waitForOperation(ctx context.Context, operationID string, timeout time.Duration) (string, error) {
+	op, err := waitForOperationCompletion(ctx, r.client, operationID, timeout)
+	if err != nil {
+		return "", err
+	}
 
-			switch op.Status {
-			case "completed":
-				// Extract site ID from operation data or resource_id
-				if op.ResourceID != "" {
-					return op.ResourceID, nil
-				}
-				// If ResourceID is not set, try to extract from Data
-				if op.Data != nil {
-					if dataMap, ok := op.Data.(map[string]interface{}); ok {
-						if siteID, ok := dataMap["site_id"].(string); ok {
-							return siteID, nil
-						}
-					}
-				}
-				return "", fmt.Errorf("operation completed but site ID not found")
-			case "failed":
-				if op.Error != nil {
-					return "", fmt.Errorf("operation failed: %s", *op.Error)
-				}
-				return "", fmt.Errorf("operation failed with unknown error")
+	// Extract site ID from operation data or resource_id
+	if op.ResourceID != "" {
+		return op.ResourceID, nil
+	}
+	// If ResourceID is not set, try to extract from Data
+	if op.Data != nil {
+		if dataMap, ok := op.Data.(map[string]interface{}); ok {
+			if siteID, ok := dataMap["site_id"].(string); ok {
+				return siteID, nil
 			}
-		case <-timeout:
-			return "", fmt.Errorf("operation timed out after 10 minutes")
-		case <-ctx.Done():
-			return "", ctx.Err()
 		}
 	}
+	return "", fmt.Errorf("operation completed but site ID not found")
 }
 
 // mapSiteToModel maps API response to Terraform model
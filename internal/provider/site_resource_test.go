@@ -0,0 +1,307 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func testSiteResource(t *testing.T) (*SiteResource, *sevallaapitest.Server) {
+	t.Helper()
+
+	operationPollInterval = time.Millisecond
+	t.Cleanup(func() { operationPollInterval = 5 * time.Second })
+
+	server := sevallaapitest.NewServer(t)
+	r := &SiteResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+	return r, server
+}
+
+func TestWaitForOperation(t *testing.T) {
+	t.Run("completed", func(t *testing.T) {
+		r, _ := testSiteResource(t)
+
+		siteID, err := r.waitForOperation(context.Background(), sevallaapitest.OperationID, time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if siteID != sevallaapitest.SiteID {
+			t.Errorf("expected site id %q, got %q", sevallaapitest.SiteID, siteID)
+		}
+	})
+
+	t.Run("uses the given timeout", func(t *testing.T) {
+		r, server := testSiteResource(t)
+		server.HandleJSON(http.MethodGet, "/operations/{id}", http.StatusOK,
+			strings.Replace(sevallaapitest.OperationFixture, `"status": "completed"`, `"status": "running"`, 1))
+
+		_, err := r.waitForOperation(context.Background(), sevallaapitest.OperationID, 20*time.Millisecond)
+		if err == nil || !strings.Contains(err.Error(), "timed out after 20ms") {
+			t.Fatalf("expected a timeout error, got %v", err)
+		}
+	})
+}
+
+func TestWaitForOperation_ReportsProgress(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	r, server := testSiteResource(t)
+	responses := []string{
+		`{"id": "op-1", "status": "running", "progress": 40, "message": "Installing WordPress"}`,
+		`{"id": "op-1", "status": "running", "progress": 40, "message": "Installing WordPress"}`,
+		`{"id": "op-1", "status": "running", "progress": 80, "message": "Configuring domain"}`,
+		sevallaapitest.OperationFixture,
+	}
+	var calls int
+	server.Handle(http.MethodGet, "/operations/{id}", func(w http.ResponseWriter, req *http.Request) {
+		sevallaapitest.JSONResponse(http.StatusOK, responses[min(calls, len(responses)-1)])(w, req)
+		calls++
+	})
+
+	if _, err := r.waitForOperation(ctx, sevallaapitest.OperationID, time.Second); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	logged := output.String()
+	if got := strings.Count(logged, "Waiting for operation"); got != 2 {
+		t.Errorf("expected a progress entry for each change, got %d in %s", got, logged)
+	}
+	for _, want := range []string{`"progress":40`, `"message":"Installing WordPress"`, `"progress":80`} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("expected the log to contain %s, got %s", want, logged)
+		}
+	}
+}
+
+func TestJitteredInterval(t *testing.T) {
+	interval := 5 * time.Second
+	for range 100 {
+		if got := jitteredInterval(interval); got < interval || got >= interval+time.Second {
+			t.Fatalf("expected an interval in [5s, 6s), got %s", got)
+		}
+	}
+	if got := jitteredInterval(time.Nanosecond); got != time.Nanosecond {
+		t.Errorf("expected an interval too short to jitter to be kept, got %s", got)
+	}
+}
+
+func TestWaitForDeletion(t *testing.T) {
+	_, server := testSiteResource(t)
+	client := sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})
+
+	get := func(ctx context.Context) error {
+		_, err := client.Sites.Get(ctx, sevallaapitest.SiteID)
+		return err
+	}
+
+	if err := waitForDeletion(context.Background(), time.Millisecond, 20*time.Millisecond, get); err == nil {
+		t.Fatal("expected a timeout while the site still exists")
+	}
+
+	server.HandleJSON(http.MethodGet, "/sites/{id}", http.StatusNotFound, `{"message":"Site not found","status":404}`)
+	if err := waitForDeletion(context.Background(), time.Millisecond, time.Second, get); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestDeleteRetryingConflicts(t *testing.T) {
+	conflict := &sevallaapi.APIError{StatusCode: http.StatusConflict, Message: "Resource is in use"}
+
+	t.Run("conflict clears", func(t *testing.T) {
+		var calls int
+		err := deleteRetryingConflicts(context.Background(), time.Millisecond, time.Second, func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return conflict
+			}
+			return nil
+		})
+		if err != nil || calls != 3 {
+			t.Errorf("expected success on the third attempt, got %v after %d calls", err, calls)
+		}
+	})
+
+	t.Run("conflict persists", func(t *testing.T) {
+		err := deleteRetryingConflicts(context.Background(), time.Millisecond, 20*time.Millisecond, func(context.Context) error {
+			return conflict
+		})
+		if !sevallaapi.IsConflict(err) {
+			t.Errorf("expected the conflict to be returned after the timeout, got %v", err)
+		}
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		var calls int
+		err := deleteRetryingConflicts(context.Background(), time.Millisecond, time.Second, func(context.Context) error {
+			calls++
+			return &sevallaapi.APIError{StatusCode: http.StatusBadRequest, Message: "Bad request"}
+		})
+		if err == nil || calls != 1 {
+			t.Errorf("expected the error after one call, got %v after %d calls", err, calls)
+		}
+	})
+}
+
+// testSiteConfig returns a site object value with the given attributes set on top of display_name
+// and the required installation settings.
+func testSiteConfig(objectType tftypes.Object, attributes map[string]tftypes.Value) tftypes.Value {
+	values := testNullValues(objectType)
+	values["display_name"] = tftypes.NewValue(tftypes.String, "My WP Site")
+	values["region"] = tftypes.NewValue(tftypes.String, "us-central1")
+	values["site_title"] = tftypes.NewValue(tftypes.String, "My Blog")
+	values["admin_user"] = tftypes.NewValue(tftypes.String, "admin")
+	values["admin_email"] = tftypes.NewValue(tftypes.String, "admin@example.com")
+	values["admin_password"] = tftypes.NewValue(tftypes.String, "secret")
+	values["wp_language"] = tftypes.NewValue(tftypes.String, "en_US")
+	for name, value := range attributes {
+		values[name] = value
+	}
+	return tftypes.NewValue(objectType, values)
+}
+
+func TestSiteResourceCreate_InstallSettings(t *testing.T) {
+	ctx := context.Background()
+	r, server := testSiteResource(t)
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	plan := testSiteConfig(objectType, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"company_id":  tftypes.NewValue(tftypes.String, sevallaapitest.CompanyID),
+		"region":      tftypes.NewValue(tftypes.String, "europe-west3"),
+		"wp_language": tftypes.NewValue(tftypes.String, "de_DE"),
+		"woocommerce": tftypes.NewValue(tftypes.Bool, true),
+	})
+	resp := &fwresource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	requests := server.Requests()
+	if len(requests) == 0 || requests[0].Method != http.MethodPost || requests[0].Path != "/sites" {
+		t.Fatalf("expected the site to be created first, got %+v", requests)
+	}
+	body := string(requests[0].Body)
+	for _, want := range []string{
+		`"company":"company-1"`, `"region":"europe-west3"`, `"site_title":"My Blog"`, `"admin_user":"admin"`,
+		`"admin_email":"admin@example.com"`, `"admin_password":"secret"`, `"wp_language":"de_DE"`, `"woocommerce":true`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected create body to contain %s, got %s", want, body)
+		}
+	}
+	if strings.Contains(body, "is_multisite") {
+		t.Errorf("expected unset settings to be omitted, got %s", body)
+	}
+
+	var data SiteResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if data.Region.ValueString() != "europe-west3" || !data.WooCommerce.ValueBool() {
+		t.Errorf("expected the install settings to be kept in state, got region %s and woocommerce %s", data.Region, data.WooCommerce)
+	}
+}
+
+func TestSiteResourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := &SiteResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	tests := map[string]struct {
+		attributes map[string]tftypes.Value
+		wantErrors int
+	}{
+		"defaults": {nil, 0},
+		"subdomain multisite": {map[string]tftypes.Value{
+			"is_multisite":           tftypes.NewValue(tftypes.Bool, true),
+			"is_subdomain_multisite": tftypes.NewValue(tftypes.Bool, true),
+		}, 0},
+		"subdomain without multisite": {map[string]tftypes.Value{
+			"is_subdomain_multisite": tftypes.NewValue(tftypes.Bool, true),
+		}, 1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := fwresource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: testSiteConfig(objectType, tt.attributes)},
+			}
+			resp := &fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, req, resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != tt.wantErrors {
+				t.Errorf("expected %d errors, got %d: %v", tt.wantErrors, got, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestSiteResourceSchema_InstallSettings(t *testing.T) {
+	ctx := context.Background()
+	r := &SiteResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	for _, name := range []string{"region", "site_title", "admin_user", "admin_email", "admin_password", "wp_language"} {
+		attribute, ok := schemaResp.Schema.Attributes[name].(schema.StringAttribute)
+		if !ok {
+			t.Fatalf("expected %s to be a string attribute, got %T", name, schemaResp.Schema.Attributes[name])
+		}
+		if !attribute.IsRequired() {
+			t.Errorf("expected %s to be required", name)
+		}
+	}
+}
+
+func TestRequiresReplaceIfCreatedWith(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		state, plan types.String
+		want        bool
+	}{
+		"changed":  {types.StringValue("us-central1"), types.StringValue("europe-west3"), true},
+		"same":     {types.StringValue("us-central1"), types.StringValue("us-central1"), false},
+		"imported": {types.StringNull(), types.StringValue("us-central1"), false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := planmodifier.StringRequest{
+				State:       tfsdk.State{Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})},
+				Plan:        tfsdk.Plan{Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})},
+				ConfigValue: tt.plan,
+				StateValue:  tt.state,
+				PlanValue:   tt.plan,
+			}
+			resp := &planmodifier.StringResponse{PlanValue: tt.plan}
+			requiresReplaceIfCreatedWith().PlanModifyString(ctx, req, resp)
+
+			if resp.RequiresReplace != tt.want {
+				t.Errorf("expected RequiresReplace %t, got %t", tt.want, resp.RequiresReplace)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &StaticSiteDeploymentResource{}
+
+const defaultStaticSiteDeploymentCreateTimeout = 15 * time.Minute
+
+func NewStaticSiteDeploymentResource() resource.Resource {
+	return &StaticSiteDeploymentResource{}
+}
+
+// StaticSiteDeploymentResource defines the resource implementation.
+type StaticSiteDeploymentResource struct {
+	client *sevallaapi.Client
+}
+
+// StaticSiteDeploymentResourceModel describes the resource data model.
+type StaticSiteDeploymentResourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	StaticSiteID  types.String   `tfsdk:"static_site_id"`
+	Branch        types.String   `tfsdk:"branch"`
+	Triggers      types.Map      `tfsdk:"triggers"`
+	Status        types.String   `tfsdk:"status"`
+	CommitSHA     types.String   `tfsdk:"commit_sha"`
+	CommitMessage types.String   `tfsdk:"commit_message"`
+	CreatedAt     types.Int64    `tfsdk:"created_at"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *StaticSiteDeploymentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_static_site_deployment"
+}
+
+func (r *StaticSiteDeploymentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Triggers a deployment of a Sevalla static site and waits for it to finish. " +
+			"Changing any argument triggers a new deployment. Destroying the resource only removes it from state.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier of the deployment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"static_site_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the static site to deploy.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"branch": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "The git branch to deploy, for example a feature branch to preview. " +
+					"Defaults to the static site's `default_branch`, which is left unchanged. Set to the branch that was deployed.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values that trigger a new deployment when changed.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The final status of the deployment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"commit_sha": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SHA of the commit that was deployed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"commit_message": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The message of the commit that was deployed. Null if the API did not report one.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp when the deployment was created.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *StaticSiteDeploymentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(SevallaProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected SevallaProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *StaticSiteDeploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data StaticSiteDeploymentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deployReq := sevallaapi.DeployStaticSiteRequest{
+		StaticSiteID: data.StaticSiteID.ValueString(),
+	}
+	if !data.Branch.IsNull() && !data.Branch.IsUnknown() {
+		deployReq.Branch = data.Branch.ValueString()
+	}
+
+	tflog.Debug(ctx, "Triggering static site deployment", map[string]interface{}{
+		"static_site_id": deployReq.StaticSiteID,
+		"branch":         deployReq.Branch,
+	})
+
+	deployed, err := r.client.StaticSites.Deploy(ctx, deployReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to deploy static site, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(deployed.Deployment.ID)
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultStaticSiteDeploymentCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deployment, err := r.waitForStaticSiteDeployment(ctx, data.ID.ValueString(), createTimeout)
+	if deployment != nil {
+		mapStaticSiteDeploymentToModel(&data, deployment)
+	} else {
+		if deployReq.Branch == "" {
+			data.Branch = types.StringNull()
+		}
+		data.Status = types.StringNull()
+		data.CommitSHA = types.StringNull()
+		data.CommitMessage = types.StringNull()
+		data.CreatedAt = types.Int64Null()
+	}
+
+	// Save the deployment even if it failed so it is tainted and redeployed on the next apply
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if errors.Is(err, errDeploymentFailed) {
+		resp.Diagnostics.AddError(
+			"Deployment Failed",
+			fmt.Sprintf("Deployment %s of static site %s finished with status %q.",
+				data.ID.ValueString(), data.StaticSiteID.ValueString(), deployment.Status),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for static site deployment, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "Created static site deployment resource")
+}
+
+func (r *StaticSiteDeploymentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data StaticSiteDeploymentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deployment, err := r.client.StaticSites.GetDeployment(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read static site deployment, got error: %s", err))
+		return
+	}
+
+	mapStaticSiteDeploymentToModel(&data, &deployment.Deployment)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StaticSiteDeploymentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data StaticSiteDeploymentResourceModel
+
+	// Every other argument forces replacement, so only the timeouts can change in place
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StaticSiteDeploymentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data StaticSiteDeploymentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deployments are historical records and cannot be deleted
+	tflog.Debug(ctx, "Removing static site deployment from state", map[string]interface{}{
+		"deployment_id": data.ID.ValueString(),
+	})
+}
+
+// waitForStaticSiteDeployment polls the deployment until it succeeds, fails or is cancelled.
+// It returns errDeploymentFailed together with the deployment if it did not succeed.
+func (r *StaticSiteDeploymentResource) waitForStaticSiteDeployment(
+	ctx context.Context,
+	deploymentID string,
+	timeout time.Duration,
+) (*sevallaapi.StaticSiteDeployment, error) {
+	ctx, cancel := r.client.WithDeadline(ctx)
+	defer cancel()
+
+	ticker := time.NewTicker(deploymentPollInterval)
+	defer ticker.Stop()
+	deadline := time.After(timeout)
+
+	for {
+		select {
+		case <-ticker.C:
+			response, err := r.client.StaticSites.GetDeployment(ctx, deploymentID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get static site deployment status: %w", err)
+			}
+
+			deployment := &response.Deployment
+			tflog.Debug(ctx, "Polled static site deployment status", map[string]interface{}{
+				"deployment_id": deploymentID,
+				"status":        deployment.Status,
+			})
+
+			switch sevallaapi.DeploymentStatus(deployment.Status) {
+			case sevallaapi.DeploymentStatusSuccess:
+				return deployment, nil
+			case sevallaapi.DeploymentStatusFailed, sevallaapi.DeploymentStatusCancelled:
+				return deployment, errDeploymentFailed
+			}
+		case <-deadline:
+			return nil, fmt.Errorf("static site deployment did not finish after %s", timeout)
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+	}
+}
+
+func mapStaticSiteDeploymentToModel(data *StaticSiteDeploymentResourceModel, deployment *sevallaapi.StaticSiteDeployment) {
+	data.Branch = types.StringValue(deployment.Branch)
+	data.Status = types.StringValue(deployment.Status)
+	data.CommitSHA = types.StringPointerValue(deployment.CommitSHA)
+	data.CommitMessage = types.StringPointerValue(deployment.CommitMessage)
+	data.CreatedAt = types.Int64Value(deployment.CreatedAt)
+}
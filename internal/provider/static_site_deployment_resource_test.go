@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestAccStaticSiteDeploymentResource(t *testing.T) {
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []tfresource.TestStep{
+			{
+				Config: testAccStaticSiteResourceConfig("test-site-deployment") + `
+resource "sevalla_static_site_deployment" "test" {
+  static_site_id = sevalla_static_site.test.id
+  branch         = "main"
+}
+`,
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttrSet("sevalla_static_site_deployment.test", "id"),
+					tfresource.TestCheckResourceAttr("sevalla_static_site_deployment.test", "branch", "main"),
+					tfresource.TestCheckResourceAttr("sevalla_static_site_deployment.test", "status", "success"),
+				),
+			},
+		},
+	})
+}
+
+// testStaticSiteDeploymentServer serves the static site deployment fixture for the given
+// branch, reporting the given statuses in sequence.
+func testStaticSiteDeploymentServer(t *testing.T, branch string, statuses ...string) (*StaticSiteDeploymentResource, *sevallaapitest.Server) {
+	t.Helper()
+
+	deploymentPollInterval = time.Millisecond
+	t.Cleanup(func() { deploymentPollInterval = 10 * time.Second })
+
+	server := sevallaapitest.NewServer(t)
+	var calls int
+	server.Handle(http.MethodGet, "/static-sites/deployments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		body := strings.Replace(sevallaapitest.StaticSiteDeploymentFixture, `"status": "success"`, fmt.Sprintf(`"status": %q`, status), 1)
+		body = strings.Replace(body, `"branch": "main"`, fmt.Sprintf(`"branch": %q`, branch), 1)
+		sevallaapitest.JSONResponse(http.StatusOK, body)(w, r)
+	})
+
+	r := &StaticSiteDeploymentResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+	return r, server
+}
+
+func testStaticSiteDeploymentCreate(t *testing.T, r *StaticSiteDeploymentResource, branch tftypes.Value) (StaticSiteDeploymentResourceModel, *resource.CreateResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := testNullValues(objectType)
+	values["static_site_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.StaticSiteID)
+	values["branch"] = branch
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Create(ctx, req, resp)
+
+	var data StaticSiteDeploymentResourceModel
+	if !resp.State.Raw.IsNull() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	}
+	return data, resp
+}
+
+func TestStaticSiteDeploymentResourceCreate(t *testing.T) {
+	t.Run("branch override", func(t *testing.T) {
+		r, server := testStaticSiteDeploymentServer(t, "feature/preview", "waiting", "inProgress", "success")
+
+		data, resp := testStaticSiteDeploymentCreate(t, r, tftypes.NewValue(tftypes.String, "feature/preview"))
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if data.ID.ValueString() != sevallaapitest.StaticSiteDeploymentID {
+			t.Errorf("expected id %q, got %s", sevallaapitest.StaticSiteDeploymentID, data.ID)
+		}
+		if data.Branch.ValueString() != "feature/preview" {
+			t.Errorf("expected the deployed branch, got %s", data.Branch)
+		}
+		if data.CommitSHA.ValueString() != "e4f5a6b" || data.CommitMessage.ValueString() != "Update landing page" {
+			t.Errorf("unexpected commit %s %s", data.CommitSHA, data.CommitMessage)
+		}
+
+		deploy := server.Requests()[0]
+		if !strings.Contains(string(deploy.Body), `"branch":"feature/preview"`) {
+			t.Errorf("expected the branch override to be sent, got %s", deploy.Body)
+		}
+		for _, req := range server.Requests() {
+			if req.Method == http.MethodPut {
+				t.Errorf("expected the static site itself not to be updated, got PUT %s", req.Path)
+			}
+		}
+	})
+
+	t.Run("default branch", func(t *testing.T) {
+		r, server := testStaticSiteDeploymentServer(t, "main", "success")
+
+		data, resp := testStaticSiteDeploymentCreate(t, r, tftypes.NewValue(tftypes.String, tftypes.UnknownValue))
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if data.Branch.ValueString() != "main" {
+			t.Errorf("expected the default branch to be recorded, got %s", data.Branch)
+		}
+		if deploy := server.Requests()[0]; strings.Contains(string(deploy.Body), "branch") {
+			t.Errorf("expected no branch to be sent, got %s", deploy.Body)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		r, _ := testStaticSiteDeploymentServer(t, "main", "inProgress", "cancelled")
+
+		data, resp := testStaticSiteDeploymentCreate(t, r, tftypes.NewValue(tftypes.String, "main"))
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected an error diagnostic")
+		}
+		if data.Status.ValueString() != "cancelled" {
+			t.Errorf("expected the cancelled deployment to be saved, got %s", data.Status)
+		}
+	})
+}
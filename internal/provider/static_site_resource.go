@@ -26,7 +26,8 @@ func NewStaticSiteResource() resource.Resource {
 
 // StaticSiteResource defines the resource implementation.
 type StaticSiteResource struct {
-	client *sevallaapi.Client
+	client    *sevallaapi.Client
+	companyID string
 }
 
 // StaticSiteResourceModel describes the resource data model.
@@ -71,8 +72,12 @@ func (r *StaticSiteResource) Schema(ctx context.Context, req resource.SchemaRequ
 				MarkdownDescription: "The display name of the static site.",
 			},
 			"company_id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The company ID that owns this static site.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The company ID that owns this static site. Defaults to the provider's `company_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"repo_url": schema.StringAttribute{
 				Required:            true,
@@ -132,6 +137,7 @@ func (r *StaticSiteResource) Configure(ctx context.Context, req resource.Configu
 	}
 
 	r.client = data.Client
+	r.companyID = data.CompanyID
 }
 
 func (r *StaticSiteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -142,6 +148,12 @@ func (r *StaticSiteResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	data.CompanyID = companyIDOrDefault(data.CompanyID, r.companyID)
+	if data.CompanyID.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("company_id"), "Missing Company ID", missingCompanyIDDetail)
+		return
+	}
+
 	createReq := sevallaapi.CreateStaticSiteRequest{
 		CompanyID:   data.CompanyID.ValueString(),
 		DisplayName: data.DisplayName.ValueString(),
@@ -153,6 +165,18 @@ func (r *StaticSiteResource) Create(ctx context.Context, req resource.CreateRequ
 		createReq.Branch = &branch
 	}
 
+	if !data.BuildCommand.IsNull() {
+		createReq.BuildCommand = stringPointer(data.BuildCommand.ValueString())
+	}
+
+	if !data.NodeVersion.IsNull() {
+		createReq.NodeVersion = stringPointer(data.NodeVersion.ValueString())
+	}
+
+	if !data.PublishedDirectory.IsNull() {
+		createReq.PublishedDirectory = stringPointer(data.PublishedDirectory.ValueString())
+	}
+
 	tflog.Debug(ctx, "Creating static site", map[string]interface{}{
 		"company_id":   createReq.CompanyID,
 		"display_name": createReq.DisplayName,
@@ -165,18 +189,27 @@ func (r *StaticSiteResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	data.ID = types.StringValue(site.StaticSite.ID)
-	data.Name = types.StringValue(site.StaticSite.Name)
-	data.DisplayName = types.StringValue(site.StaticSite.DisplayName)
-	data.Status = types.StringValue(site.StaticSite.Status)
-	data.RepoURL = types.StringValue(site.StaticSite.RepoURL)
-	data.DefaultBranch = types.StringValue(site.StaticSite.DefaultBranch)
-	data.AutoDeploy = types.BoolValue(site.StaticSite.AutoDeploy)
-	data.GitType = types.StringValue(site.StaticSite.GitType)
-	data.Hostname = types.StringValue(site.StaticSite.Hostname)
-
-	if site.StaticSite.BuildCommand != nil {
-		data.BuildCommand = types.StringValue(*site.StaticSite.BuildCommand)
+	mapStaticSiteToModel(&data, &site.StaticSite)
+
+	// The create endpoint may ignore the build settings. The build command is the only one echoed
+	// back, so when it was dropped all of them are applied with an update instead.
+	if createReq.BuildCommand != nil && (site.StaticSite.BuildCommand == nil || *site.StaticSite.BuildCommand != *createReq.BuildCommand) {
+		tflog.Debug(ctx, "Create ignored the build settings, applying them with an update", map[string]interface{}{
+			"id": site.StaticSite.ID,
+		})
+
+		updated, err := r.client.StaticSites.Update(ctx, site.StaticSite.ID, sevallaapi.UpdateStaticSiteRequest{
+			BuildCommand:       createReq.BuildCommand,
+			NodeVersion:        createReq.NodeVersion,
+			PublishedDirectory: createReq.PublishedDirectory,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to apply static site build settings, got error: %s", err))
+			// Save the created site so it is tainted and replaced instead of left behind
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+		mapStaticSiteToModel(&data, &updated.StaticSite)
 	}
 
 	tflog.Trace(ctx, "Created static site resource")
@@ -198,19 +231,7 @@ func (r *StaticSiteResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	data.ID = types.StringValue(site.StaticSite.ID)
-	data.Name = types.StringValue(site.StaticSite.Name)
-	data.DisplayName = types.StringValue(site.StaticSite.DisplayName)
-	data.Status = types.StringValue(site.StaticSite.Status)
-	data.RepoURL = types.StringValue(site.StaticSite.RepoURL)
-	data.DefaultBranch = types.StringValue(site.StaticSite.DefaultBranch)
-	data.AutoDeploy = types.BoolValue(site.StaticSite.AutoDeploy)
-	data.GitType = types.StringValue(site.StaticSite.GitType)
-	data.Hostname = types.StringValue(site.StaticSite.Hostname)
-
-	if site.StaticSite.BuildCommand != nil {
-		data.BuildCommand = types.StringValue(*site.StaticSite.BuildCommand)
-	}
+	mapStaticSiteToModel(&data, &site.StaticSite)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -223,6 +244,8 @@ func (r *StaticSiteResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	data.CompanyID = companyIDOrDefault(data.CompanyID, r.companyID)
+
 	updateReq := sevallaapi.UpdateStaticSiteRequest{}
 
 	if !data.DisplayName.IsNull() {
@@ -284,3 +307,28 @@ func (r *StaticSiteResource) Delete(ctx context.Context, req resource.DeleteRequ
 func (r *StaticSiteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// mapStaticSiteToModel maps API response to Terraform model
+func mapStaticSiteToModel(data *StaticSiteResourceModel, site *sevallaapi.StaticSiteDetails) {
+	data.ID = types.StringValue(site.ID)
+	data.Name = types.StringValue(site.Name)
+	data.DisplayName = types.StringValue(site.DisplayName)
+	data.Status = types.StringValue(site.Status)
+	data.RepoURL = types.StringValue(site.RepoURL)
+	data.DefaultBranch = types.StringValue(site.DefaultBranch)
+	data.AutoDeploy = types.BoolValue(site.AutoDeploy)
+	data.GitType = types.StringValue(site.GitType)
+	data.Hostname = types.StringValue(site.Hostname)
+
+	if site.BuildCommand != nil {
+		data.BuildCommand = types.StringValue(*site.BuildCommand)
+	}
+
+	if site.NodeVersion != nil {
+		data.NodeVersion = types.StringValue(*site.NodeVersion)
+	}
+
+	if site.PublishedDirectory != nil {
+		data.PublishedDirectory = types.StringValue(*site.PublishedDirectory)
+	}
+}
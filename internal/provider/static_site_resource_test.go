@@ -1,10 +1,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
 )
 
 func TestAccStaticSiteResource(t *testing.T) {
@@ -95,3 +103,123 @@ resource "sevalla_static_site" "test" {
 }
 `, name, testAccCompanyID())
 }
+
+// testStaticSiteCreate creates a static site with build settings against server, reads it back
+// and returns the state after each step.
+func testStaticSiteCreate(t *testing.T, server *sevallaapitest.Server) (created, read tfsdk.State) {
+	t.Helper()
+	ctx := context.Background()
+
+	r := &StaticSiteResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, tftypes.UnknownValue)
+	}
+	values["display_name"] = tftypes.NewValue(tftypes.String, "my-site")
+	values["company_id"] = tftypes.NewValue(tftypes.String, sevallaapitest.CompanyID)
+	values["repo_url"] = tftypes.NewValue(tftypes.String, "https://github.com/example/my-site")
+	values["default_branch"] = tftypes.NewValue(tftypes.String, "main")
+	values["auto_deploy"] = tftypes.NewValue(tftypes.Bool, true)
+	values["build_command"] = tftypes.NewValue(tftypes.String, "npm run build")
+	values["node_version"] = tftypes.NewValue(tftypes.String, "18.16.0")
+	values["published_directory"] = tftypes.NewValue(tftypes.String, "dist")
+
+	createResp := &fwresource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	return createResp.State, readResp.State
+}
+
+func TestStaticSiteResourceCreate_BuildSettings(t *testing.T) {
+	server := sevallaapitest.NewServer(t)
+
+	created, read := testStaticSiteCreate(t, server)
+	if !created.Raw.Equal(read.Raw) {
+		t.Errorf("expected the first read to match the created state, got %s and %s", created.Raw, read.Raw)
+	}
+
+	requests := server.Requests()
+	if len(requests) == 0 || requests[0].Method != http.MethodPost {
+		t.Fatalf("expected the site to be created first, got %+v", requests)
+	}
+	for _, want := range []string{`"build_command":"npm run build"`, `"node_version":"18.16.0"`, `"published_directory":"dist"`} {
+		if !strings.Contains(string(requests[0].Body), want) {
+			t.Errorf("expected create body to contain %s, got %s", want, requests[0].Body)
+		}
+	}
+	for _, req := range requests {
+		if req.Method == http.MethodPut {
+			t.Errorf("expected no follow-up update, got %s %s", req.Method, req.Path)
+		}
+	}
+}
+
+func TestStaticSiteResourceCreate_BuildSettingsFallback(t *testing.T) {
+	server := sevallaapitest.NewServer(t)
+	server.HandleJSON(http.MethodPost, "/static-sites", http.StatusOK,
+		strings.Replace(sevallaapitest.StaticSiteFixture, `"build_command": "npm run build"`, `"build_command": null`, 1))
+
+	created, read := testStaticSiteCreate(t, server)
+	if !created.Raw.Equal(read.Raw) {
+		t.Errorf("expected the first read to match the created state, got %s and %s", created.Raw, read.Raw)
+	}
+
+	var update *sevallaapitest.Request
+	for _, req := range server.Requests() {
+		if req.Method == http.MethodPut {
+			update = &req
+		}
+	}
+	if update == nil {
+		t.Fatal("expected the ignored build settings to be applied with an update")
+	}
+	for _, want := range []string{`"build_command":"npm run build"`, `"node_version":"18.16.0"`, `"published_directory":"dist"`} {
+		if !strings.Contains(string(update.Body), want) {
+			t.Errorf("expected update body to contain %s, got %s", want, update.Body)
+		}
+	}
+}
+
+func TestStaticSiteResourceRead_BuildSettingsDrift(t *testing.T) {
+	ctx := context.Background()
+	server := sevallaapitest.NewServer(t)
+	created, _ := testStaticSiteCreate(t, server)
+
+	// The node version was changed in the dashboard
+	server.HandleJSON(http.MethodGet, "/static-sites/{id}", http.StatusOK,
+		strings.Replace(sevallaapitest.StaticSiteFixture, `"node_version": "18.16.0"`, `"node_version": "20.2.0"`, 1))
+
+	r := &StaticSiteResource{client: sevallaapi.NewClient(sevallaapi.Config{BaseURL: server.URL, Token: "test-token"})}
+	resp := &fwresource.ReadResponse{State: created}
+	r.Read(ctx, fwresource.ReadRequest{State: created}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data StaticSiteResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if data.NodeVersion.ValueString() != "20.2.0" {
+		t.Errorf("expected the dashboard node version to be read so the configured 18.16.0 shows a diff, got %s", data.NodeVersion)
+	}
+	if data.PublishedDirectory.ValueString() != "dist" {
+		t.Errorf("expected published_directory dist, got %s", data.PublishedDirectory)
+	}
+}
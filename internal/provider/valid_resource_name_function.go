@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidResourceNameFunction{}
+
+func NewValidResourceNameFunction() function.Function {
+	return &ValidResourceNameFunction{}
+}
+
+// ValidResourceNameFunction defines the function implementation.
+type ValidResourceNameFunction struct{}
+
+func (f *ValidResourceNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "valid_resource_name"
+}
+
+func (f *ValidResourceNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Reports whether a string follows conservative Sevalla resource naming rules.",
+		MarkdownDescription: fmt.Sprintf("Returns `true` if the name follows conservative rules for the `display_name` of an "+
+			"application, database or static site: %d to %d characters long, and it %s. The API documents no limits on "+
+			"display names, so the resources accept names that break these rules.",
+			resourceNameMinLength, resourceNameMaxLength, resourceNameRulesDescription),
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "The name to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *ValidResourceNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, validateResourceName(name) == nil))
+}
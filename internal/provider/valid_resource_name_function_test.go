@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidResourceNameFunction(t *testing.T) {
+	tests := map[string]struct {
+		name string
+		want bool
+	}{
+		"simple":          {"my-app", true},
+		"spaces and dots": {"My App v1.2", true},
+		"underscores":     {"api_worker", true},
+		"max length":      {strings.Repeat("a", resourceNameMaxLength), true},
+		"empty":           {"", false},
+		"too long":        {strings.Repeat("a", resourceNameMaxLength+1), false},
+		"leading hyphen":  {"-app", false},
+		"leading space":   {" app", false},
+		"slash":           {"team/app", false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.name)})}
+			resp := &function.RunResponse{Result: function.NewResultData(types.BoolUnknown())}
+			NewValidResourceNameFunction().Run(context.Background(), req, resp)
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+			got, ok := resp.Result.Value().(types.Bool)
+			if !ok {
+				t.Fatalf("expected a bool result, got %T", resp.Result.Value())
+			}
+			if got.ValueBool() != tt.want {
+				t.Errorf("expected %t, got %t", tt.want, got.ValueBool())
+			}
+		})
+	}
+}
+
+func TestResourceDisplayNamesAreNotRestricted(t *testing.T) {
+	ctx := context.Background()
+
+	// The naming rules are not documented by the API, so names it accepts must still plan
+	for name, r := range map[string]fwresource.Resource{
+		"application": &ApplicationResource{},
+		"database":    &DatabaseResource{},
+		"static site": &StaticSiteResource{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var schemaResp fwresource.SchemaResponse
+			r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+			attribute, ok := schemaResp.Schema.Attributes["display_name"].(schema.StringAttribute)
+			if !ok {
+				t.Fatalf("expected display_name to be a string attribute, got %T", schemaResp.Schema.Attributes["display_name"])
+			}
+			if len(attribute.Validators) != 0 {
+				t.Errorf("expected display_name to have no validators, got %d", len(attribute.Validators))
+			}
+		})
+	}
+}
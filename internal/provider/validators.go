@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// Conservative rules for the display names of applications, databases and static sites, checked
+// by the valid_resource_name function. The API documents no limits, so the resources do not
+// enforce them. Names start with a letter or digit, followed by letters, digits, spaces, dots,
+// hyphens or underscores.
+const (
+	resourceNameMinLength = 1
+	resourceNameMaxLength = 64
+)
+
+var resourceNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 ._-]*$`)
+
+const resourceNameRulesDescription = "must start with a letter or digit and contain only letters, digits, spaces, dots, hyphens or underscores"
+
+// validateResourceName reports why name is not a valid Sevalla resource name, or nil if it is.
+func validateResourceName(name string) error {
+	if length := utf8.RuneCountInString(name); length < resourceNameMinLength || length > resourceNameMaxLength {
+		return fmt.Errorf("must be between %d and %d characters long, got %d", resourceNameMinLength, resourceNameMaxLength, length)
+	}
+	if !resourceNamePattern.MatchString(name) {
+		return fmt.Errorf("%s", resourceNameRulesDescription)
+	}
+	return nil
+}
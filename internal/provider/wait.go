@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi"
+)
+
+// waitForDeletion polls get until it fails with a 404, which means the resource is gone.
+func waitForDeletion(ctx context.Context, interval, timeout time.Duration, get func(context.Context) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	deadline := time.After(timeout)
+
+	for {
+		select {
+		case <-ticker.C:
+			err := get(ctx)
+			var apiErr *sevallaapi.APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to check deletion status: %w", err)
+			}
+		case <-deadline:
+			return fmt.Errorf("resource was still present after %s", timeout)
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+
+// deleteConflictRetryInterval is how long deleteRetryingConflicts waits before sending a
+// delete again after the API rejected it with 409 Conflict.
+var deleteConflictRetryInterval = 10 * time.Second
+
+// deleteRetryingConflicts calls del until it stops failing with 409 Conflict. The API
+// rejects deleting a resource that another one still depends on, such as a database with
+// an application connected to it, and when the whole stack is destroyed the dependent
+// resource is usually already being deleted, so the conflict clears on its own.
+func deleteRetryingConflicts(ctx context.Context, interval, timeout time.Duration, del func(context.Context) error) error {
+	deadline := time.After(timeout)
+
+	for {
+		err := del(ctx)
+		if !sevallaapi.IsConflict(err) {
+			return err
+		}
+
+		tflog.Debug(ctx, "Delete was rejected with a conflict, retrying", map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		select {
+		case <-time.After(interval):
+		case <-deadline:
+			return fmt.Errorf("%w (still conflicting after %s)", err, timeout)
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+
+// pollJitterFraction is the largest share of the poll interval added at random to each wait
+// between operation polls, so many resources created at once do not poll in lockstep.
+const pollJitterFraction = 0.2
+
+// jitteredInterval returns interval plus a random delay of up to pollJitterFraction of it.
+func jitteredInterval(interval time.Duration) time.Duration {
+	maxJitter := int64(float64(interval) * pollJitterFraction)
+	if maxJitter <= 0 {
+		return interval
+	}
+	return interval + time.Duration(rand.Int64N(maxJitter))
+}
+
+// waitForOperationCompletion polls an operation until it completes and returns it.
+// A failed operation is returned as an error. Progress and message changes are logged at
+// info level while the operation runs.
+func waitForOperationCompletion(
+	ctx context.Context,
+	client *sevallaapi.Client,
+	operationID string,
+	timeout time.Duration,
+) (*sevallaapi.Operation, error) {
+	ctx, cancel := client.WithDeadline(ctx)
+	defer cancel()
+
+	timer := time.NewTimer(jitteredInterval(operationPollInterval))
+	defer timer.Stop()
+	deadline := time.After(timeout)
+	var last *sevallaapi.Operation
+
+	for {
+		select {
+		case <-timer.C:
+			op, err := client.Operations.GetStatus(ctx, operationID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get operation status: %w", err)
+			}
+
+			switch op.Status {
+			case "completed":
+				return op, nil
+			case "failed":
+				if op.Error != nil {
+					return nil, fmt.Errorf("operation failed: %s", *op.Error)
+				}
+				return nil, fmt.Errorf("operation failed with unknown error")
+			}
+
+			if last == nil || op.Status != last.Status || op.Progress != last.Progress || op.Message != last.Message {
+				tflog.Info(ctx, "Waiting for operation", map[string]interface{}{
+					"operation_id": operationID,
+					"status":       op.Status,
+					"progress":     op.Progress,
+					"message":      op.Message,
+				})
+			}
+			last = op
+			timer.Reset(jitteredInterval(operationPollInterval))
+		case <-deadline:
+			return nil, fmt.Errorf("operation timed out after %s", timeout)
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+	}
+}
@@ -3,12 +3,17 @@ package sevallaapi
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -22,6 +27,28 @@ type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
 	Token      string
+	UserAgent  string
+
+	// Deadline, when set, bounds every request and wait made through the client.
+	Deadline time.Time
+
+	// RequestTimeout, when set, bounds each individual request, so a single hung connection
+	// cannot use up a caller's longer deadline.
+	RequestTimeout time.Duration
+
+	// ExtraHeaders are sent with every request, except for ReservedHeaders, which the client sets itself.
+	ExtraHeaders map[string]string
+
+	// Compression asks the API for gzip-encoded responses and decompresses them before decoding.
+	Compression bool
+
+	// RetryAttempts is how many times PostIdempotent retries a create that was rate limited or
+	// could not connect, waiting RetryDelay before each retry.
+	RetryAttempts int
+	RetryDelay    time.Duration
+
+	// DebugHTTP logs every request and response, with sensitive values redacted.
+	DebugHTTP bool
 
 	// Services
 	Applications *ApplicationService
@@ -34,12 +61,90 @@ type Client struct {
 	Operations   *OperationService
 }
 
+// APIError is returned when the API responds with an error status code.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden {
+		return fmt.Sprintf("HTTP %d: %s (check that the API token in the provider configuration or SEVALLA_TOKEN "+
+			"is valid and has access to this resource)", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+// IsAuthError reports whether err is the API rejecting the token, either because it is
+// invalid or expired (401) or because it lacks access (403).
+func IsAuthError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
+
+// ErrOperationDeadlineExceeded is returned when the client's Deadline passes before a request or wait completes.
+var ErrOperationDeadlineExceeded = errors.New("provider operation deadline exceeded")
+
+// ErrRequestTimeout is returned when a single request takes longer than the client's RequestTimeout.
+var ErrRequestTimeout = errors.New("request timed out")
+
+// ErrDeploymentNotFound is returned when a deployment is not in its application's deployment history.
+var ErrDeploymentNotFound = errors.New("deployment not found")
+
+// ErrConflict is returned by conditional updates when the resource changed after it was last read.
+var ErrConflict = errors.New("resource was modified since it was last read")
+
+// IsConflict reports whether err indicates a conditional update lost a race with another writer.
+func IsConflict(err error) bool {
+	if errors.Is(err, ErrConflict) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusConflict || apiErr.StatusCode == http.StatusPreconditionFailed)
+}
+
 type Config struct {
-	BaseURL string
-	Token   string
-	Timeout time.Duration
+	BaseURL   string
+	Token     string
+	Timeout   time.Duration
+	UserAgent string
+
+	// Deadline bounds all requests made through the client. The zero value means no deadline.
+	Deadline time.Time
+
+	// RequestTimeout bounds each request unless the caller's context already ends sooner.
+	// The zero value leaves requests bounded by Timeout and the caller's context only.
+	RequestTimeout time.Duration
+
+	// ExtraHeaders are sent with every request, for example to satisfy an API gateway.
+	ExtraHeaders map[string]string
+
+	// Compression requests gzip-encoded responses. It also applies to a custom HTTPClient
+	// whose transport has compression disabled.
+	Compression bool
+
+	// RetryAttempts and RetryDelay configure retries of creates. The zero value does not retry.
+	RetryAttempts int
+	RetryDelay    time.Duration
+
+	// DebugHTTP logs the method, URL, status and bodies of every request at debug level, with
+	// passwords, tokens and environment variable values redacted.
+	DebugHTTP bool
+
+	// HTTPClient is used verbatim when set; Timeout and the connection pool settings are ignored.
+	HTTPClient *http.Client
+
+	// Connection pool settings for the default transport. Zero values keep the net/http defaults.
+	MaxIdleConns    int
+	MaxConnsPerHost int
+	IdleConnTimeout time.Duration
 }
 
+// ReservedHeaders are set by the client on every request and cannot be replaced by ExtraHeaders.
+var ReservedHeaders = []string{"Authorization", "Content-Type", "Accept", "Accept-Encoding", "User-Agent"}
+
 // NewClient creates a new Sevalla API client with the provided configuration.
 func NewClient(config Config) *Client {
 	if config.BaseURL == "" {
@@ -49,12 +154,32 @@ func NewClient(config Config) *Client {
 		config.Timeout = DefaultTimeout
 	}
 
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout:   config.Timeout,
+			Transport: NewTransport(config),
+		}
+	}
+
 	client := &Client{
-		BaseURL: config.BaseURL,
-		HTTPClient: &http.Client{
-			Timeout: config.Timeout,
-		},
-		Token: config.Token,
+		BaseURL:        config.BaseURL,
+		HTTPClient:     httpClient,
+		Token:          config.Token,
+		UserAgent:      config.UserAgent,
+		Deadline:       config.Deadline,
+		RequestTimeout: config.RequestTimeout,
+		Compression:    config.Compression,
+		RetryAttempts:  config.RetryAttempts,
+		RetryDelay:     config.RetryDelay,
+		DebugHTTP:      config.DebugHTTP,
+	}
+
+	if len(config.ExtraHeaders) > 0 {
+		client.ExtraHeaders = make(map[string]string, len(config.ExtraHeaders))
+		for key, value := range config.ExtraHeaders {
+			client.ExtraHeaders[key] = value
+		}
 	}
 
 	// Initialize services
@@ -70,31 +195,220 @@ func NewClient(config Config) *Client {
 	return client
 }
 
+// NewTransport clones the default transport, applying the connection pool settings from config.
+// Callers that need a proxy or custom TLS settings can adjust it and pass it in via Config.HTTPClient.
+func NewTransport(config Config) *http.Transport {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = defaultTransport.Clone()
+	}
+
+	if config.MaxIdleConns > 0 {
+		transport.MaxIdleConns = config.MaxIdleConns
+		transport.MaxIdleConnsPerHost = config.MaxIdleConns
+	}
+	if config.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = config.MaxConnsPerHost
+	}
+	if config.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = config.IdleConnTimeout
+	}
+
+	return transport
+}
+
+// WithDeadline returns a copy of ctx that is cancelled with ErrOperationDeadlineExceeded as its
+// cause once the client's Deadline passes. Without a deadline, ctx is returned unchanged.
+func (c *Client) WithDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.Deadline.IsZero() {
+		return ctx, func() {}
+	}
+	return context.WithDeadlineCause(ctx, c.Deadline, ErrOperationDeadlineExceeded)
+}
+
+// withRequestTimeout applies the client's Deadline and RequestTimeout to a single request.
+// The request timeout only takes effect when ctx has no deadline or a later one, so polling
+// loops with a long overall timeout still give up on each hung request early.
+func (c *Client) withRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancelDeadline := c.WithDeadline(ctx)
+	if c.RequestTimeout <= 0 {
+		return ctx, cancelDeadline
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= c.RequestTimeout {
+		return ctx, cancelDeadline
+	}
+
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, c.RequestTimeout, ErrRequestTimeout)
+	return ctx, func() {
+		cancelTimeout()
+		cancelDeadline()
+	}
+}
+
+// ValidateAuth checks the client's token against the API and returns the details of the API key.
+// A rejected token results in an *APIError with status 401.
+func (c *Client) ValidateAuth(ctx context.Context) (*AuthValidationResponse, error) {
+	var auth AuthValidationResponse
+	if err := c.Get(ctx, "/validate", &auth); err != nil {
+		return nil, err
+	}
+	return &auth, nil
+}
+
+// Ping checks that the API can be reached by requesting the cheap token validation endpoint.
+// Any HTTP response counts as reachable, including one rejecting the token, so only transport
+// failures such as DNS errors, refused connections and timeouts are returned.
+func (c *Client) Ping(ctx context.Context) error {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/validate", nil)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// Expiry returns when the API key expires, or false if it never expires or the timestamp is not valid.
+func (r *AuthValidationResponse) Expiry() (time.Time, bool) {
+	if r.ExpiresAt == nil {
+		return time.Time{}, false
+	}
+	millis, err := strconv.ParseInt(*r.ExpiresAt, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(millis), true
+}
+
+// gzipBody decompresses a gzip-encoded response body and closes the underlying body with it.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b gzipBody) Close() error {
+	_ = b.Reader.Close()
+	return b.body.Close()
+}
+
+// decompressBody replaces a gzip-encoded response body with its decompressed content.
+// Bodies the transport already decompressed, and empty ones, are left as they are.
+func decompressBody(resp *http.Response) error {
+	if resp.Uncompressed || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decompress response: %w", err)
+	}
+	resp.Body = gzipBody{Reader: reader, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// cancelOnClose releases a request's deadline context once its response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func isReservedHeader(key string) bool {
+	for _, reserved := range ReservedHeaders {
+		if strings.EqualFold(key, reserved) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Client) makeRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	return c.makeRequestWithHeaders(ctx, method, path, body, nil)
+}
+
+func (c *Client) makeRequestWithHeaders(
+	ctx context.Context,
+	method, path string,
+	body interface{},
+	headers map[string]string,
+) (*http.Response, error) {
 	var reqBody io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
 		reqBody = bytes.NewReader(jsonBody)
 	}
 
+	// url.JoinPath escapes "?", so the query string is split off and re-attached
+	path, rawQuery, _ := strings.Cut(path, "?")
 	reqURL, err := url.JoinPath(c.BaseURL, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct URL: %w", err)
 	}
+	if rawQuery != "" {
+		reqURL += "?" + rawQuery
+	}
 
+	ctx, cancel := c.withRequestTimeout(ctx)
 	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	for key, value := range c.ExtraHeaders {
+		if !isReservedHeader(key) {
+			req.Header.Set(key, value)
+		}
+	}
 	req.Header.Set("Authorization", "Bearer "+c.Token)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	if c.Compression {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
 
-	return c.HTTPClient.Do(req)
+	start := time.Now()
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		c.logHTTP(ctx, req, jsonBody, nil, err, time.Since(start))
+		cause := context.Cause(ctx)
+		cancel()
+		if errors.Is(cause, ErrOperationDeadlineExceeded) || errors.Is(cause, ErrRequestTimeout) {
+			return nil, fmt.Errorf("%s %s: %w", method, path, cause)
+		}
+		return nil, err
+	}
+	if err := decompressBody(resp); err != nil {
+		_ = resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	c.logHTTP(ctx, req, jsonBody, resp, nil, time.Since(start))
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
 }
 
 func (c *Client) Get(ctx context.Context, path string, result interface{}) error {
@@ -113,7 +427,58 @@ func (c *Client) Get(ctx context.Context, path string, result interface{}) error
 }
 
 func (c *Client) Post(ctx context.Context, path string, body interface{}, result interface{}) error {
-	resp, err := c.makeRequest(ctx, "POST", path, body)
+	return c.post(ctx, path, body, result, nil)
+}
+
+// PostIdempotent sends a POST request that creates a resource, with an Idempotency-Key header
+// that is reused by its retries. The API does not document support for the key, so a create is
+// only retried when it cannot have reached the server: on a 429 response or when the connection
+// could not be made. Timeouts, dropped connections and 5xx responses are returned at once, since
+// the resource may already have been created. Retries happen up to RetryAttempts times.
+func (c *Client) PostIdempotent(ctx context.Context, path string, body interface{}, result interface{}) error {
+	headers := map[string]string{"Idempotency-Key": newIdempotencyKey()}
+	for attempt := 0; ; attempt++ {
+		err := c.post(ctx, path, body, result, headers)
+		if attempt >= c.RetryAttempts || !isRetryable(ctx, err) {
+			return err
+		}
+
+		select {
+		case <-time.After(c.RetryDelay):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// newIdempotencyKey returns a random version 4 UUID.
+func newIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// isRetryable reports whether a failed create can be sent again without risking a duplicate: the
+// API rejected it with 429, or the connection failed before the request was sent. Failures caused
+// by the caller's context or the client's Deadline are final.
+func isRetryable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, ErrOperationDeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	// A failed dial, such as a refused connection or an unknown host, sends nothing
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func (c *Client) post(ctx context.Context, path string, body interface{}, result interface{}, headers map[string]string) error {
+	resp, err := c.makeRequestWithHeaders(ctx, "POST", path, body, headers)
 	if err != nil {
 		return err
 	}
@@ -150,6 +515,27 @@ func (c *Client) Put(ctx context.Context, path string, body interface{}, result
 	return nil
 }
 
+// PutIfMatch sends a PUT request with an If-Match header so the API can reject
+// the update if the resource no longer matches the given version.
+func (c *Client) PutIfMatch(ctx context.Context, path, version string, body interface{}, result interface{}) error {
+	resp, err := c.makeRequestWithHeaders(ctx, "PUT", path, body, map[string]string{"If-Match": version})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	const httpBadRequestThreshold = 400
+	if resp.StatusCode >= httpBadRequestThreshold {
+		return c.handleError(resp)
+	}
+
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+
+	return nil
+}
+
 func (c *Client) Delete(ctx context.Context, path string) error {
 	resp, err := c.makeRequest(ctx, "DELETE", path, nil)
 	if err != nil {
@@ -165,10 +551,30 @@ func (c *Client) Delete(ctx context.Context, path string) error {
 	return nil
 }
 
+// DeleteWithBody sends a DELETE request with a JSON body, for endpoints that take what to delete in the body.
+func (c *Client) DeleteWithBody(ctx context.Context, path string, body interface{}, result interface{}) error {
+	resp, err := c.makeRequest(ctx, "DELETE", path, body)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	const httpBadRequestThreshold = 400
+	if resp.StatusCode >= httpBadRequestThreshold {
+		return c.handleError(resp)
+	}
+
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+
+	return nil
+}
+
 func (c *Client) handleError(resp *http.Response) error {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("HTTP %d: failed to read error response", resp.StatusCode)
+		return &APIError{StatusCode: resp.StatusCode, Message: "failed to read error response"}
 	}
 
 	var errorResponse struct {
@@ -177,17 +583,17 @@ func (c *Client) handleError(resp *http.Response) error {
 	}
 
 	if err := json.Unmarshal(body, &errorResponse); err != nil {
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		return &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
 	}
 
 	if errorResponse.Message != "" {
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, errorResponse.Message)
+		return &APIError{StatusCode: resp.StatusCode, Message: errorResponse.Message}
 	}
 	if errorResponse.Error != "" {
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, errorResponse.Error)
+		return &APIError{StatusCode: resp.StatusCode, Message: errorResponse.Error}
 	}
 
-	return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	return &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
 }
 
 // Pipeline convenience methods.
@@ -0,0 +1,490 @@
+package sevallaapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func TestNewClient_HTTPClient(t *testing.T) {
+	custom := &http.Client{Timeout: time.Minute}
+
+	client := NewClient(Config{HTTPClient: custom, Timeout: time.Second, MaxIdleConns: 1})
+	if client.HTTPClient != custom {
+		t.Error("expected the configured HTTP client to be used verbatim")
+	}
+	if client.HTTPClient.Timeout != time.Minute {
+		t.Errorf("expected the custom timeout to be kept, got %s", client.HTTPClient.Timeout)
+	}
+}
+
+func TestNewClient_Transport(t *testing.T) {
+	client := NewClient(Config{
+		MaxIdleConns:    5,
+		MaxConnsPerHost: 15,
+		IdleConnTimeout: time.Minute,
+	})
+
+	if client.HTTPClient.Timeout != DefaultTimeout {
+		t.Errorf("expected default timeout, got %s", client.HTTPClient.Timeout)
+	}
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", client.HTTPClient.Transport)
+	}
+	if transport.MaxIdleConns != 5 || transport.MaxIdleConnsPerHost != 5 {
+		t.Errorf("unexpected idle connection limits %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 15 {
+		t.Errorf("unexpected MaxConnsPerHost %d", transport.MaxConnsPerHost)
+	}
+	if transport.IdleConnTimeout != time.Minute {
+		t.Errorf("unexpected IdleConnTimeout %s", transport.IdleConnTimeout)
+	}
+	if transport.Proxy == nil {
+		t.Error("expected the default transport's proxy settings to be kept")
+	}
+}
+
+func TestClient_Deadline(t *testing.T) {
+	server := sevallaapitest.NewServer(t)
+	server.Handle(http.MethodGet, "/applications/{id}", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	t.Run("aborts in-flight requests", func(t *testing.T) {
+		client := NewClient(Config{BaseURL: server.URL, Token: "test-token", Deadline: time.Now().Add(20 * time.Millisecond)})
+
+		_, err := client.Applications.Get(context.Background(), "app-1")
+		if !errors.Is(err, ErrOperationDeadlineExceeded) {
+			t.Fatalf("expected ErrOperationDeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("passed deadline", func(t *testing.T) {
+		client := NewClient(Config{BaseURL: server.URL, Token: "test-token", Deadline: time.Now().Add(-time.Second)})
+
+		_, err := client.Databases.Get(context.Background(), sevallaapitest.DatabaseID)
+		if !errors.Is(err, ErrOperationDeadlineExceeded) {
+			t.Fatalf("expected ErrOperationDeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("no deadline", func(t *testing.T) {
+		client := NewClient(Config{BaseURL: server.URL, Token: "test-token"})
+
+		ctx, cancel := client.WithDeadline(context.Background())
+		defer cancel()
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("expected no deadline without Config.Deadline")
+		}
+		if _, err := client.Databases.Get(ctx, sevallaapitest.DatabaseID); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+}
+
+func TestClient_RequestTimeout(t *testing.T) {
+	server := sevallaapitest.NewServer(t)
+	server.Handle(http.MethodGet, "/applications/{id}", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	t.Run("aborts a hung request", func(t *testing.T) {
+		client := NewClient(Config{BaseURL: server.URL, Token: "test-token", RequestTimeout: 20 * time.Millisecond})
+
+		// The caller's own deadline is much later, as in a long polling loop
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		start := time.Now()
+		_, err := client.Applications.Get(ctx, sevallaapitest.ApplicationID)
+		if !errors.Is(err, ErrRequestTimeout) {
+			t.Fatalf("expected ErrRequestTimeout, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed >= time.Second {
+			t.Errorf("expected the request to be aborted early, took %s", elapsed)
+		}
+		if ctx.Err() != nil {
+			t.Error("expected the caller's context to be unaffected")
+		}
+	})
+
+	t.Run("caller deadline is sooner", func(t *testing.T) {
+		client := NewClient(Config{BaseURL: server.URL, Token: "test-token", RequestTimeout: time.Minute})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := client.Applications.Get(ctx, sevallaapitest.ApplicationID)
+		if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrRequestTimeout) {
+			t.Fatalf("expected the caller's deadline to end the request, got %v", err)
+		}
+	})
+
+	t.Run("caller cancellation", func(t *testing.T) {
+		client := NewClient(Config{BaseURL: server.URL, Token: "test-token", RequestTimeout: time.Minute})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		_, err := client.Applications.Get(ctx, sevallaapitest.ApplicationID)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected the cancellation to propagate, got %v", err)
+		}
+	})
+
+	t.Run("fast request", func(t *testing.T) {
+		client := NewClient(Config{BaseURL: server.URL, Token: "test-token", RequestTimeout: time.Minute})
+
+		if _, err := client.Databases.Get(context.Background(), sevallaapitest.DatabaseID); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+}
+
+func TestClient_ExtraHeaders(t *testing.T) {
+	server := sevallaapitest.NewServer(t)
+	headers := map[string]string{
+		"X-Org-Id":      "org-1",
+		"authorization": "Bearer gateway-token",
+		"Accept":        "text/html",
+	}
+	client := NewClient(Config{BaseURL: server.URL, Token: "test-token", ExtraHeaders: headers})
+	headers["X-Org-Id"] = "changed"
+
+	if _, err := client.Applications.Get(context.Background(), sevallaapitest.ApplicationID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req, ok := server.LastRequest()
+	if !ok {
+		t.Fatal("expected a request")
+	}
+	if got := req.Header.Get("X-Org-Id"); got != "org-1" {
+		t.Errorf("expected the extra header to be sent as configured, got %q", got)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer test-token" {
+		t.Errorf("expected the Authorization header to be kept, got %q", got)
+	}
+	if got := req.Header.Get("Accept"); got != "application/json" {
+		t.Errorf("expected the Accept header to be kept, got %q", got)
+	}
+}
+
+func TestClient_Compression(t *testing.T) {
+	server := sevallaapitest.NewServer(t)
+	gzipJSON := func(status int, body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+				sevallaapitest.JSONResponse(status, body)(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Encoding", "gzip")
+			w.WriteHeader(status)
+			gz := gzip.NewWriter(w)
+			_, _ = io.WriteString(gz, body)
+			_ = gz.Close()
+		}
+	}
+	server.Handle(http.MethodGet, "/applications/{id}", gzipJSON(http.StatusOK, sevallaapitest.ApplicationFixture))
+	server.Handle(http.MethodGet, "/databases/{id}", gzipJSON(http.StatusNotFound, `{"message":"Database not found","status":404}`))
+
+	// The default transport negotiates gzip on its own, so it is disabled to exercise the client's handling
+	newClient := func(compression bool) *Client {
+		return NewClient(Config{
+			BaseURL:     server.URL,
+			Token:       "test-token",
+			Compression: compression,
+			HTTPClient:  &http.Client{Transport: &http.Transport{DisableCompression: true}},
+		})
+	}
+
+	t.Run("gzip response", func(t *testing.T) {
+		app, err := newClient(true).Applications.Get(context.Background(), sevallaapitest.ApplicationID)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if app.App.ID != sevallaapitest.ApplicationID {
+			t.Errorf("expected the gzip-encoded application to be decoded, got %+v", app.App)
+		}
+
+		req, _ := server.LastRequest()
+		if got := req.Header.Get("Accept-Encoding"); got != "gzip" {
+			t.Errorf("expected gzip to be requested, got %q", got)
+		}
+	})
+
+	t.Run("gzip error", func(t *testing.T) {
+		_, err := newClient(true).Databases.Get(context.Background(), sevallaapitest.DatabaseID)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "Database not found" {
+			t.Errorf("expected the gzip-encoded error to be decoded, got %v", err)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		if _, err := newClient(false).Applications.Get(context.Background(), sevallaapitest.ApplicationID); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		req, _ := server.LastRequest()
+		if got := req.Header.Get("Accept-Encoding"); got != "" {
+			t.Errorf("expected no Accept-Encoding header, got %q", got)
+		}
+	})
+}
+
+func TestClient_PostIdempotent(t *testing.T) {
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	newClient := func(t *testing.T, statuses ...int) (*Client, *sevallaapitest.Server) {
+		t.Helper()
+		server := sevallaapitest.NewServer(t)
+		var calls int
+		server.Handle(http.MethodPost, "/applications", func(w http.ResponseWriter, r *http.Request) {
+			status := statuses[min(calls, len(statuses)-1)]
+			calls++
+			if status != http.StatusOK {
+				sevallaapitest.JSONResponse(status, `{"message":"try again"}`)(w, r)
+				return
+			}
+			sevallaapitest.JSONResponse(http.StatusOK, sevallaapitest.ApplicationFixture)(w, r)
+		})
+		client := NewClient(Config{BaseURL: server.URL, Token: "test-token", RetryAttempts: 2, RetryDelay: time.Millisecond})
+		return client, server
+	}
+
+	t.Run("retried create reuses the key", func(t *testing.T) {
+		client, server := newClient(t, http.StatusTooManyRequests, http.StatusOK)
+
+		if _, err := client.Applications.Create(context.Background(), CreateApplicationRequest{DisplayName: "my-app"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		requests := server.Requests()
+		if len(requests) != 2 {
+			t.Fatalf("expected the create to be retried once, got %d requests", len(requests))
+		}
+		key := requests[0].Header.Get("Idempotency-Key")
+		if !uuidPattern.MatchString(key) {
+			t.Errorf("expected a UUID idempotency key, got %q", key)
+		}
+		if retried := requests[1].Header.Get("Idempotency-Key"); retried != key {
+			t.Errorf("expected the retry to reuse key %q, got %q", key, retried)
+		}
+
+		if _, err := client.Applications.Create(context.Background(), CreateApplicationRequest{DisplayName: "other-app"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		last, _ := server.LastRequest()
+		if last.Header.Get("Idempotency-Key") == key {
+			t.Error("expected a new create to use a new key")
+		}
+	})
+
+	t.Run("server errors are not retried", func(t *testing.T) {
+		for _, status := range []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable} {
+			client, server := newClient(t, status, http.StatusOK)
+
+			_, err := client.Applications.Create(context.Background(), CreateApplicationRequest{DisplayName: "my-app"})
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != status {
+				t.Fatalf("expected an HTTP %d error, got %v", status, err)
+			}
+			if got := len(server.Requests()); got != 1 {
+				t.Errorf("expected a create that may have succeeded not to be repeated after HTTP %d, got %d requests", status, got)
+			}
+		}
+	})
+
+	t.Run("timeouts are not retried", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		server.Handle(http.MethodPost, "/applications", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(50 * time.Millisecond)
+			sevallaapitest.JSONResponse(http.StatusOK, sevallaapitest.ApplicationFixture)(w, r)
+		})
+		client := NewClient(Config{
+			BaseURL: server.URL, Token: "test-token", RequestTimeout: 5 * time.Millisecond,
+			RetryAttempts: 2, RetryDelay: time.Millisecond,
+		})
+
+		if _, err := client.Applications.Create(context.Background(), CreateApplicationRequest{DisplayName: "my-app"}); !errors.Is(err, ErrRequestTimeout) {
+			t.Fatalf("expected a request timeout, got %v", err)
+		}
+		if got := len(server.Requests()); got != 1 {
+			t.Errorf("expected a single request, got %d", got)
+		}
+	})
+
+	t.Run("refused connections are retried", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		addr := listener.Addr().String()
+		_ = listener.Close()
+
+		client := NewClient(Config{BaseURL: "http://" + addr, Token: "test-token"})
+		_, err = client.Applications.Create(context.Background(), CreateApplicationRequest{DisplayName: "my-app"})
+		if err == nil || !isRetryable(context.Background(), err) {
+			t.Errorf("expected a refused connection to be retryable, got %v", err)
+		}
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		client, server := newClient(t, http.StatusBadRequest)
+
+		_, err := client.Applications.Create(context.Background(), CreateApplicationRequest{DisplayName: "my-app"})
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected a bad request error, got %v", err)
+		}
+		if got := len(server.Requests()); got != 1 {
+			t.Errorf("expected a single request, got %d", got)
+		}
+	})
+
+	t.Run("auth failures are not retried", func(t *testing.T) {
+		for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+			client, server := newClient(t, status)
+			client.RetryAttempts = 5
+			client.RetryDelay = time.Hour
+
+			_, err := client.Applications.Create(context.Background(), CreateApplicationRequest{DisplayName: "my-app"})
+			if !IsAuthError(err) {
+				t.Fatalf("expected an auth error for HTTP %d, got %v", status, err)
+			}
+			if !strings.Contains(err.Error(), "SEVALLA_TOKEN") {
+				t.Errorf("expected the error to mention SEVALLA_TOKEN, got %q", err)
+			}
+			if got := len(server.Requests()); got != 1 {
+				t.Errorf("expected a single request for HTTP %d, got %d", status, got)
+			}
+		}
+	})
+
+	t.Run("attempts are bounded", func(t *testing.T) {
+		client, server := newClient(t, http.StatusTooManyRequests)
+
+		if _, err := client.Applications.Create(context.Background(), CreateApplicationRequest{DisplayName: "my-app"}); err == nil {
+			t.Fatal("expected the last error to be returned")
+		}
+		if got := len(server.Requests()); got != 3 {
+			t.Errorf("expected the first attempt and two retries, got %d requests", got)
+		}
+	})
+}
+
+func TestClient_Ping(t *testing.T) {
+	t.Run("reachable", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		client := NewClient(Config{BaseURL: server.URL, Token: "test-token"})
+
+		if err := client.Ping(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		req, _ := server.LastRequest()
+		if req.Path != "/validate" {
+			t.Errorf("unexpected request to %s", req.Path)
+		}
+	})
+
+	t.Run("rejected token is still reachable", func(t *testing.T) {
+		server := sevallaapitest.NewServer(t)
+		server.HandleJSON(http.MethodGet, "/validate", http.StatusUnauthorized, `{"message":"Unauthorized","status":401}`)
+		client := NewClient(Config{BaseURL: server.URL, Token: "bad-token"})
+
+		if err := client.Ping(context.Background()); err != nil {
+			t.Errorf("expected a rejected token to count as reachable, got %s", err)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		client := NewClient(Config{BaseURL: server.URL, Token: "test-token"})
+
+		if err := client.Ping(context.Background()); err == nil {
+			t.Error("expected an error for an API that cannot be reached")
+		}
+	})
+}
+
+func TestClient_DebugHTTP(t *testing.T) {
+	newClient := func(t *testing.T, debug bool) (*Client, *bytes.Buffer, context.Context) {
+		t.Helper()
+		var output bytes.Buffer
+		ctx := tflogtest.RootLogger(context.Background(), &output)
+		server := sevallaapitest.NewServer(t)
+		return NewClient(Config{BaseURL: server.URL, Token: "test-token", DebugHTTP: debug}), &output, ctx
+	}
+
+	t.Run("redacts secrets", func(t *testing.T) {
+		client, output, ctx := newClient(t, true)
+
+		if err := client.Post(ctx, "/databases", CreateDatabaseRequest{DisplayName: "my-db", DBPassword: "hunter2"}, &struct{}{}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		db, err := client.Databases.Get(ctx, "db-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if db.Database.Data.DBPassword != "secret" {
+			t.Errorf("expected the logged response to still be decoded, got password %q", db.Database.Data.DBPassword)
+		}
+
+		logged := output.String()
+		for _, secret := range []string{"hunter2", "secret", "test-token"} {
+			if strings.Contains(logged, secret) {
+				t.Errorf("expected %q to be redacted, got %s", secret, logged)
+			}
+		}
+		for _, want := range []string{`"method":"POST"`, `"status":200`, "my-db-abc12-postgresql.svc.cluster.local", redactedValue} {
+			if !strings.Contains(logged, want) {
+				t.Errorf("expected the log to contain %s, got %s", want, logged)
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		client, output, ctx := newClient(t, false)
+
+		if _, err := client.Databases.Get(ctx, "db-1"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if output.Len() != 0 {
+			t.Errorf("expected no logs, got %s", output.String())
+		}
+	})
+}
+
+func TestRedactBody(t *testing.T) {
+	for body, want := range map[string]string{
+		``:                          ``,
+		`not json`:                  `not json`,
+		`{"db_password":"x","n":1}`: `{"db_password":"[REDACTED]","n":1}`,
+		`{"environment_variables":[{"key":"API_KEY","value":"x"}]}`: `{"environment_variables":[{"key":"API_KEY","value":"[REDACTED]"}]}`,
+		`{"db_root_password":null}`:                                 `{"db_root_password":null}`,
+	} {
+		if got := redactBody([]byte(body)); got != want {
+			t.Errorf("redactBody(%s) = %s, want %s", body, got, want)
+		}
+	}
+}
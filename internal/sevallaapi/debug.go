@@ -0,0 +1,119 @@
+package sevallaapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// debugHTTPBodyLimit is how many bytes of each request and response body are logged when
+// DebugHTTP is set. Longer bodies are truncated.
+const debugHTTPBodyLimit = 16 * 1024
+
+// redactedValue replaces sensitive values in logged bodies.
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeyParts mark JSON object keys whose values are redacted from logged bodies. Keys
+// are matched case-insensitively by substring, so "db_password" and "db_root_password" are
+// both covered by "password".
+var sensitiveKeyParts = []string{"password", "token", "secret", "authorization", "connection_string", "api_key"}
+
+// isSensitiveKey reports whether the value of a JSON object key should be redacted. Environment
+// variable values are sent and returned as {"key": ..., "value": ...} objects, so "value" is
+// always redacted.
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if key == "value" {
+		return true
+	}
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactBody returns body for logging with sensitive JSON values replaced. Bodies that are not
+// JSON are returned as they are, and the result is truncated to debugHTTPBodyLimit.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var decoded interface{}
+	logged := string(body)
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		if redacted, err := json.Marshal(redactValue(decoded)); err == nil {
+			logged = string(redacted)
+		}
+	}
+
+	if len(logged) > debugHTTPBodyLimit {
+		return fmt.Sprintf("%s... (%d bytes truncated)", logged[:debugHTTPBodyLimit], len(logged)-debugHTTPBodyLimit)
+	}
+	return logged
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if isSensitiveKey(key) && nested != nil {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = redactValue(nested)
+		}
+	}
+	return value
+}
+
+// logHTTP writes a debug log entry for a request when DebugHTTP is set. The response body is
+// read in full and replaced with an in-memory copy, so callers can still decode it.
+func (c *Client) logHTTP(ctx context.Context, req *http.Request, reqBody []byte, resp *http.Response, err error, elapsed time.Duration) {
+	if !c.DebugHTTP {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"method":       req.Method,
+		"url":          req.URL.String(),
+		"duration_ms":  elapsed.Milliseconds(),
+		"request_body": redactBody(reqBody),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Sevalla API request failed", fields)
+		return
+	}
+
+	respBody, readErr := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if readErr != nil {
+		// Leave the partial body for the caller, which reports the read error when decoding.
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(respBody), errReader{readErr}))
+		fields["error"] = readErr.Error()
+	}
+
+	fields["status"] = resp.StatusCode
+	fields["response_body"] = redactBody(respBody)
+	tflog.Debug(ctx, "Sevalla API request", fields)
+}
+
+// errReader returns err once the bytes before it have been read.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
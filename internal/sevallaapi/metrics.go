@@ -0,0 +1,87 @@
+package sevallaapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// MetricsDateFormat is the layout of the dates in a MetricsQuery.
+const MetricsDateFormat = "2006-01-02"
+
+// MetricsIntervals maps the supported MetricsQuery intervals to their length in seconds.
+var MetricsIntervals = map[string]int{
+	"hour":  int(time.Hour / time.Second),
+	"day":   int(24 * time.Hour / time.Second),
+	"week":  int(7 * 24 * time.Hour / time.Second),
+	"month": int(30 * 24 * time.Hour / time.Second),
+}
+
+// query returns the metrics query parameters, prefixed with "?". The timeframe runs from the
+// start of StartDate to the end of EndDate, in UTC.
+func (q MetricsQuery) query() (string, error) {
+	start, err := time.Parse(MetricsDateFormat, q.StartDate)
+	if err != nil {
+		return "", fmt.Errorf("start date %q is not in YYYY-MM-DD format", q.StartDate)
+	}
+	end, err := time.Parse(MetricsDateFormat, q.EndDate)
+	if err != nil {
+		return "", fmt.Errorf("end date %q is not in YYYY-MM-DD format", q.EndDate)
+	}
+	if end.Before(start) {
+		return "", fmt.Errorf("end date %s is before start date %s", q.EndDate, q.StartDate)
+	}
+	seconds, ok := MetricsIntervals[q.Interval]
+	if !ok {
+		return "", fmt.Errorf("interval %q must be one of hour, day, week or month", q.Interval)
+	}
+
+	values := url.Values{}
+	values.Set("interval_in_seconds", strconv.Itoa(seconds))
+	values.Set("timeframe_start", start.Format(time.RFC3339))
+	values.Set("timeframe_end", end.AddDate(0, 0, 1).Format(time.RFC3339))
+	return "?" + values.Encode(), nil
+}
+
+// MetricValue is a metric data point value. The API reports some metrics as numbers and
+// others as numeric strings.
+type MetricValue float64
+
+func (v *MetricValue) UnmarshalJSON(data []byte) error {
+	var number float64
+	if err := json.Unmarshal(data, &number); err == nil {
+		*v = MetricValue(number)
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("metric value %s is neither a number nor a string", data)
+	}
+	number, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return fmt.Errorf("metric value %q is not numeric: %w", text, err)
+	}
+	*v = MetricValue(number)
+	return nil
+}
+
+// MetricPoint is a single data point of a metrics series.
+type MetricPoint struct {
+	Time  string      `json:"time"`
+	Value MetricValue `json:"value"`
+}
+
+// splitMetricPoints splits a series into its timestamps and values. An empty series results in
+// empty, non-nil slices.
+func splitMetricPoints(points []MetricPoint) ([]string, []float64) {
+	timeframe := make([]string, 0, len(points))
+	data := make([]float64, 0, len(points))
+	for _, point := range points {
+		timeframe = append(timeframe, point.Time)
+		data = append(data, float64(point.Value))
+	}
+	return timeframe, data
+}
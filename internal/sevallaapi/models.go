@@ -24,6 +24,7 @@ type ApplicationDetails struct {
 	DockerComposeFile    string               `json:"docker_compose_file,omitempty"`
 	StartCommand         string               `json:"start_command,omitempty"`
 	InstallCommand       string               `json:"install_command,omitempty"`
+	Hostname             string               `json:"hostname,omitempty"`
 	EnvironmentVariables []EnvVar             `json:"environment_variables,omitempty"`
 	CreatedAt            int64                `json:"created_at"`
 	UpdatedAt            int64                `json:"updated_at"`
@@ -62,6 +63,23 @@ type AppDeployment struct {
 	BuildLogs     string  `json:"build_logs,omitempty"`
 }
 
+// ApplicationDeployment is a single application deployment looked up by its ID.
+type ApplicationDeployment struct {
+	ID            string  `json:"id"`
+	AppID         string  `json:"app_id"`
+	Status        string  `json:"status"`
+	RepoURL       string  `json:"repo_url"`
+	Branch        string  `json:"branch"`
+	CommitSHA     *string `json:"commit_sha"`
+	CommitMessage *string `json:"commit_message"`
+	CreatedAt     int64   `json:"created_at"`
+}
+
+// ApplicationDeploymentResponse represents the API response for a single application deployment.
+type ApplicationDeploymentResponse struct {
+	Deployment ApplicationDeployment `json:"deployment"`
+}
+
 // AppProcess represents a process within an application.
 type AppProcess struct {
 	ID               string           `json:"id"`
@@ -94,6 +112,12 @@ type ScalingStrategy struct {
 	Config map[string]interface{} `json:"config"` // Different configs based on type
 }
 
+// UpdateProcessRequest represents the request to update an application process.
+type UpdateProcessRequest struct {
+	ScalingStrategy *ScalingStrategy `json:"scaling_strategy,omitempty"`
+	Entrypoint      *string          `json:"entrypoint,omitempty"`
+}
+
 // CreateApplicationRequest represents the request to create an application.
 // Note: Application creation appears to be handled through deployments in the API.
 type CreateApplicationRequest struct {
@@ -110,6 +134,21 @@ type CreateDeploymentRequest struct {
 	CommitMessage string `json:"commit_message,omitempty"`
 }
 
+// TriggerDeploymentRequest represents the request to start a manual application deployment.
+type TriggerDeploymentRequest struct {
+	AppID       string `json:"app_id"`
+	Branch      string `json:"branch,omitempty"`
+	DockerImage string `json:"docker_image,omitempty"`
+	IsRestart   bool   `json:"is_restart,omitempty"`
+}
+
+// TriggerDeploymentResponse represents the response to a manual application deployment.
+type TriggerDeploymentResponse struct {
+	Deployment struct {
+		ID string `json:"id"`
+	} `json:"deployment"`
+}
+
 // UpdateApplicationRequest represents the request to update an application.
 type UpdateApplicationRequest struct {
 	DisplayName          *string      `json:"display_name,omitempty"`
@@ -137,6 +176,13 @@ type EnvVar struct {
 	Value string `json:"value"`
 }
 
+// GetDatabaseOptions selects which connection details a database read asks the API to
+// compute. External details are only present when External is set.
+type GetDatabaseOptions struct {
+	Internal bool
+	External bool
+}
+
 // Database represents a Sevalla database from the detailed view.
 type Database struct {
 	Database DatabaseDetails `json:"database"`
@@ -237,6 +283,8 @@ type StaticSiteDetails struct {
 	GitType            string                 `json:"git_type"`
 	Hostname           string                 `json:"hostname"`
 	BuildCommand       *string                `json:"build_command"`
+	NodeVersion        *string                `json:"node_version"`
+	PublishedDirectory *string                `json:"published_directory"`
 	CreatedAt          int64                  `json:"created_at"`
 	UpdatedAt          int64                  `json:"updated_at"`
 	Deployments        []StaticSiteDeployment `json:"deployments,omitempty"`
@@ -253,21 +301,46 @@ type StaticSiteListItem struct {
 // StaticSiteDeployment represents a deployment within a static site.
 type StaticSiteDeployment struct {
 	ID            string  `json:"id"`
+	StaticSiteID  string  `json:"static_site_id,omitempty"`
 	Status        string  `json:"status"`
 	RepoURL       string  `json:"repo_url"`
 	Branch        string  `json:"branch"`
+	CommitSHA     *string `json:"commit_sha,omitempty"`
 	CommitMessage *string `json:"commit_message"`
 	CreatedAt     int64   `json:"created_at"`
+	UpdatedAt     int64   `json:"updated_at,omitempty"`
+	FinishedAt    *int64  `json:"finished_at,omitempty"`
+}
+
+// StaticSiteDeploymentResponse represents the API response for a single static site deployment.
+type StaticSiteDeploymentResponse struct {
+	Deployment StaticSiteDeployment `json:"deployment"`
+}
+
+// DeployStaticSiteRequest represents the request to start a manual static site deployment.
+type DeployStaticSiteRequest struct {
+	StaticSiteID string `json:"static_site_id"`
+	Branch       string `json:"branch,omitempty"`
+}
+
+// DeployStaticSiteResponse represents the response to a manual static site deployment.
+type DeployStaticSiteResponse struct {
+	Deployment struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	} `json:"deployment"`
 }
 
 // CreateStaticSiteRequest represents the request to create a static site.
 // Note: Static site creation appears to be handled through deployments in the API.
 type CreateStaticSiteRequest struct {
-	CompanyID   string  `json:"company_id"`
-	DisplayName string  `json:"display_name"`
-	RepoURL     string  `json:"repo_url"`
-	Branch      *string `json:"branch,omitempty"`
-	// Add other fields as needed based on API documentation
+	CompanyID          string  `json:"company_id"`
+	DisplayName        string  `json:"display_name"`
+	RepoURL            string  `json:"repo_url"`
+	Branch             *string `json:"branch,omitempty"`
+	BuildCommand       *string `json:"build_command,omitempty"`
+	NodeVersion        *string `json:"node_version,omitempty"`
+	PublishedDirectory *string `json:"published_directory,omitempty"`
 }
 
 // UpdateStaticSiteRequest represents the request to update a static site.
@@ -328,11 +401,48 @@ type Domain struct {
 	Type string `json:"type"`
 }
 
+// AddSiteDomainRequest represents the request to add a domain to a site environment.
+type AddSiteDomainRequest struct {
+	DomainName     string `json:"domain_name"`
+	IsWildcardless bool   `json:"is_wildcardless,omitempty"`
+}
+
+// DeleteSiteDomainsRequest represents the request to remove domains from a site environment.
+type DeleteSiteDomainsRequest struct {
+	DomainIDs []string `json:"domain_ids"`
+}
+
+// CreateEnvironmentRequest represents the request to add an environment to a WordPress site.
+// The environment is a copy of CloneFromEnvironmentID when set, and empty otherwise.
+type CreateEnvironmentRequest struct {
+	DisplayName            string `json:"display_name"`
+	IsPremium              bool   `json:"is_premium"`
+	CloneFromEnvironmentID string `json:"source_env_id,omitempty"`
+}
+
+// PushEnvironmentRequest represents the request to push one site environment onto another.
+type PushEnvironmentRequest struct {
+	SourceEnvironmentID string `json:"source_env_id"`
+	TargetEnvironmentID string `json:"target_env_id"`
+	PushDB              bool   `json:"push_db"`
+	PushFiles           bool   `json:"push_files"`
+	RunSearchAndReplace bool   `json:"run_search_and_replace"`
+}
+
 // CreateSiteRequest represents the request to create a WordPress site.
 type CreateSiteRequest struct {
-	CompanyID   string `json:"company_id"`
-	DisplayName string `json:"display_name"`
-	// Add other fields as needed based on API documentation
+	CompanyID            string `json:"company"`
+	DisplayName          string `json:"display_name"`
+	Region               string `json:"region,omitempty"`
+	SiteTitle            string `json:"site_title,omitempty"`
+	AdminUser            string `json:"admin_user,omitempty"`
+	AdminEmail           string `json:"admin_email,omitempty"`
+	AdminPassword        string `json:"admin_password,omitempty"`
+	WPLanguage           string `json:"wp_language,omitempty"`
+	IsMultisite          bool   `json:"is_multisite,omitempty"`
+	IsSubdomainMultisite bool   `json:"is_subdomain_multisite,omitempty"`
+	WooCommerce          bool   `json:"woocommerce,omitempty"`
+	WordPressSEO         bool   `json:"wordpressseo,omitempty"`
 }
 
 // UpdateSiteRequest represents the request to update a WordPress site.
@@ -416,15 +526,27 @@ type PipelineStage struct {
 // CreatePipelineRequest represents the request to create a pipeline.
 type CreatePipelineRequest struct {
 	DisplayName string `json:"display_name"`
+	// Stages is a pointer so an empty list is sent as [] rather than omitted.
+	Stages *[]PipelineStageRequest `json:"stages,omitempty"`
 	// Add other fields as needed based on API documentation
 }
 
 // UpdatePipelineRequest represents the request to update a pipeline.
 type UpdatePipelineRequest struct {
 	DisplayName *string `json:"display_name,omitempty"`
+	// Stages is a pointer so that removing every stage sends [] rather than leaving them unchanged.
+	Stages *[]PipelineStageRequest `json:"stages,omitempty"`
 	// Add other updateable fields based on API specification
 }
 
+// PipelineStageRequest declares a pipeline stage. Existing stages are identified by ID;
+// stages without an ID are created.
+type PipelineStageRequest struct {
+	ID          string `json:"id,omitempty"`
+	DisplayName string `json:"display_name"`
+	Type        string `json:"type"`
+}
+
 // InternalConnection represents a connection between resources.
 type InternalConnection struct {
 	ID         string `json:"id"`
@@ -473,6 +595,24 @@ type BuildTimeMetrics struct {
 	Timeframe []string  `json:"timeframe"`
 	Data      []float64 `json:"data"`
 	Unit      string    `json:"unit"` // e.g., "seconds", "minutes"
+
+	// ResourceTypes holds the build machine size of each data point, in the same order as Data.
+	ResourceTypes []string `json:"resource_types"`
+}
+
+// BuildTimeMetricsResponse represents the API response for an application's build time metrics.
+// The API reports a separate series for each build machine size.
+type BuildTimeMetricsResponse struct {
+	App struct {
+		ID      string `json:"id"`
+		Metrics struct {
+			Unit      string `json:"unit,omitempty"`
+			BuildTime []struct {
+				ResourceType string        `json:"resource_type"`
+				Data         []MetricPoint `json:"data"`
+			} `json:"build_time"`
+		} `json:"metrics"`
+	} `json:"app"`
 }
 
 // RuntimeMetrics represents runtime performance metrics.
@@ -484,8 +624,18 @@ type RuntimeMetrics struct {
 
 // HTTPRequestMetrics represents HTTP request analytics.
 type HTTPRequestMetrics struct {
-	Timeframe []string `json:"timeframe"`
-	Data      []int64  `json:"data"`
+	Timeframe []string  `json:"timeframe"`
+	Data      []float64 `json:"data"`
+}
+
+// HTTPRequestMetricsResponse represents the API response for an application's HTTP request metrics.
+type HTTPRequestMetricsResponse struct {
+	App struct {
+		ID      string `json:"id"`
+		Metrics struct {
+			HTTPRequests []MetricPoint `json:"http_requests"`
+		} `json:"metrics"`
+	} `json:"app"`
 }
 
 // MetricsQuery represents query parameters for metrics endpoints.
@@ -531,10 +681,11 @@ type ErrorResponse struct {
 
 // AuthValidationResponse represents the response from the authentication endpoint.
 type AuthValidationResponse struct {
-	Message   string `json:"message"`
-	Status    int    `json:"status"`
-	ExpiresAt int64  `json:"expires_at"`
-	KeyID     string `json:"key_id"`
+	Name    string `json:"name"`
+	Company string `json:"company"`
+	Status  string `json:"status"`
+	// ExpiresAt is a Unix timestamp in milliseconds, sent as a string. Null for keys that never expire.
+	ExpiresAt *string `json:"expires_at"`
 }
 
 // ResourceType represents the available database resource types.
@@ -588,6 +739,12 @@ const (
 	ApplicationStatusDeployed  ApplicationStatus = "deployed"
 	ApplicationStatusFailed    ApplicationStatus = "failed"
 	ApplicationStatusStopped   ApplicationStatus = "stopped"
+
+	// Statuses reported by the v2 API.
+	ApplicationStatusDeploymentInProgress ApplicationStatus = "deploymentInProgress"
+	ApplicationStatusDeploymentSuccess    ApplicationStatus = "deploymentSuccess"
+	ApplicationStatusDeploymentFailed     ApplicationStatus = "deploymentFailed"
+	ApplicationStatusDeploymentCancelled  ApplicationStatus = "deploymentCancelled"
 )
 
 // DatabaseStatus represents the possible database states.
@@ -598,6 +755,9 @@ const (
 	DatabaseStatusActive   DatabaseStatus = "active"
 	DatabaseStatusFailed   DatabaseStatus = "failed"
 	DatabaseStatusDeleting DatabaseStatus = "deleting"
+
+	// Status reported by the v2 API once a database accepts connections.
+	DatabaseStatusReady DatabaseStatus = "ready"
 )
 
 // DeploymentStatus represents the possible deployment states.
@@ -609,4 +769,8 @@ const (
 	DeploymentStatusSuccessful DeploymentStatus = "successful"
 	DeploymentStatusFailed     DeploymentStatus = "failed"
 	DeploymentStatusCanceled   DeploymentStatus = "canceled"
+
+	// Statuses reported by the v2 API.
+	DeploymentStatusSuccess   DeploymentStatus = "success"
+	DeploymentStatusCancelled DeploymentStatus = "cancelled"
 )
@@ -0,0 +1,49 @@
+package sevallaapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultPageSize is the number of items requested per page when listing all resources.
+const DefaultPageSize = 100
+
+// ListOptions controls which page of a list endpoint is fetched.
+type ListOptions struct {
+	Limit  int
+	Offset int
+}
+
+// query returns the pagination query parameters, prefixed with "&" so they can follow the company parameter.
+func (o ListOptions) query() string {
+	if o.Limit <= 0 {
+		return fmt.Sprintf("&offset=%d", o.Offset)
+	}
+	return fmt.Sprintf("&limit=%d&offset=%d", o.Limit, o.Offset)
+}
+
+// listAll fetches pages until one comes back empty, aggregating the items.
+// The API may return fewer items than requested per page, so a short page does not end the listing.
+// Listing also stops when a page starts with an item already seen, which guards against
+// endpoints that ignore the offset and return the same page every time.
+func listAll[T any](ctx context.Context, id func(T) string, fetch func(context.Context, ListOptions) ([]T, error)) ([]T, error) {
+	var items []T
+	seen := make(map[string]bool)
+	opts := ListOptions{Limit: DefaultPageSize}
+
+	for {
+		page, err := fetch(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 || seen[id(page[0])] {
+			return items, nil
+		}
+
+		for _, item := range page {
+			seen[id(item)] = true
+		}
+		items = append(items, page...)
+		opts.Offset += len(page)
+	}
+}
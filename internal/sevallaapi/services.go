@@ -2,10 +2,25 @@ package sevallaapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
 	"time"
 )
 
+// Backoff between reads of a newly created database that the API does not return yet.
+var (
+	databaseCreateInitialBackoff = 500 * time.Millisecond
+	databaseCreateMaxBackoff     = 10 * time.Second
+)
+
+// Backoff between reads of a newly created static site whose details are not populated yet.
+var (
+	staticSiteCreateInitialBackoff = 500 * time.Millisecond
+	staticSiteCreateMaxBackoff     = 10 * time.Second
+)
+
 // ApplicationService handles application-related API operations.
 type ApplicationService struct {
 	client *Client
@@ -16,9 +31,18 @@ func NewApplicationService(client *Client) *ApplicationService {
 	return &ApplicationService{client: client}
 }
 
+// List returns all applications of the company, fetching every page.
 func (s *ApplicationService) List(ctx context.Context, companyID string) ([]ApplicationListItem, error) {
+	return listAll(ctx, func(item ApplicationListItem) string { return item.ID },
+		func(ctx context.Context, opts ListOptions) ([]ApplicationListItem, error) {
+			return s.ListPage(ctx, companyID, opts)
+		})
+}
+
+// ListPage returns a single page of the company's applications.
+func (s *ApplicationService) ListPage(ctx context.Context, companyID string, opts ListOptions) ([]ApplicationListItem, error) {
 	var response ApplicationListResponse
-	url := fmt.Sprintf("/applications?company=%s", companyID)
+	url := fmt.Sprintf("/applications?company=%s%s", companyID, opts.query())
 	err := s.client.Get(ctx, url, &response)
 	return response.Company.Apps.Items, err
 }
@@ -31,7 +55,7 @@ func (s *ApplicationService) Get(ctx context.Context, id string) (*Application,
 
 func (s *ApplicationService) Create(ctx context.Context, req CreateApplicationRequest) (*Application, error) {
 	var app Application
-	err := s.client.Post(ctx, "/applications", req, &app)
+	err := s.client.PostIdempotent(ctx, "/applications", req, &app)
 	return &app, err
 }
 
@@ -45,10 +69,103 @@ func (s *ApplicationService) Update(
 	return &app, err
 }
 
+// UpdateIfUnmodified updates an application only if its updated_at timestamp still
+// matches the one the caller last read. The API does not version applications, so the
+// timestamp is checked client-side and also sent as If-Match for servers that honor it.
+// A mismatch returns an error for which IsConflict reports true.
+func (s *ApplicationService) UpdateIfUnmodified(
+	ctx context.Context,
+	id string,
+	updatedAt int64,
+	req UpdateApplicationRequest,
+) (*Application, error) {
+	current, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.App.UpdatedAt != updatedAt {
+		return nil, fmt.Errorf("application %s was updated at %d, expected %d: %w",
+			id, current.App.UpdatedAt, updatedAt, ErrConflict)
+	}
+
+	var app Application
+	version := strconv.FormatInt(updatedAt, 10)
+	err = s.client.PutIfMatch(ctx, fmt.Sprintf("/applications/%s", id), version, req, &app)
+	return &app, err
+}
+
 func (s *ApplicationService) Delete(ctx context.Context, id string) error {
 	return s.client.Delete(ctx, fmt.Sprintf("/applications/%s", id))
 }
 
+// GetHTTPRequestMetrics returns the number of HTTP requests the application served in each interval of the query.
+func (s *ApplicationService) GetHTTPRequestMetrics(ctx context.Context, id string, query MetricsQuery) (*HTTPRequestMetrics, error) {
+	params, err := query.query()
+	if err != nil {
+		return nil, err
+	}
+
+	var response HTTPRequestMetricsResponse
+	if err := s.client.Get(ctx, fmt.Sprintf("/applications/%s/metrics/http-requests%s", id, params), &response); err != nil {
+		return nil, err
+	}
+
+	timeframe, data := splitMetricPoints(response.App.Metrics.HTTPRequests)
+	return &HTTPRequestMetrics{Timeframe: timeframe, Data: data}, nil
+}
+
+// Restart restarts the application's processes without rebuilding it. The restart runs as a deployment.
+func (s *ApplicationService) Restart(ctx context.Context, id string) (*TriggerDeploymentResponse, error) {
+	var resp TriggerDeploymentResponse
+	err := s.client.Post(ctx, "/applications/deployments", TriggerDeploymentRequest{AppID: id, IsRestart: true}, &resp)
+	return &resp, err
+}
+
+// GetBuildTimeMetrics returns how long the application's builds took in each interval of the query.
+// The series of every build machine size are combined, in the order the API reports them.
+func (s *ApplicationService) GetBuildTimeMetrics(ctx context.Context, id string, query MetricsQuery) (*BuildTimeMetrics, error) {
+	params, err := query.query()
+	if err != nil {
+		return nil, err
+	}
+
+	var response BuildTimeMetricsResponse
+	if err := s.client.Get(ctx, fmt.Sprintf("/applications/%s/metrics/build-time%s", id, params), &response); err != nil {
+		return nil, err
+	}
+
+	metrics := &BuildTimeMetrics{
+		Timeframe:     []string{},
+		Data:          []float64{},
+		ResourceTypes: []string{},
+		Unit:          response.App.Metrics.Unit,
+	}
+	for _, series := range response.App.Metrics.BuildTime {
+		timeframe, data := splitMetricPoints(series.Data)
+		metrics.Timeframe = append(metrics.Timeframe, timeframe...)
+		metrics.Data = append(metrics.Data, data...)
+		for range series.Data {
+			metrics.ResourceTypes = append(metrics.ResourceTypes, series.ResourceType)
+		}
+	}
+	return metrics, nil
+}
+
+func (s *ApplicationService) GetProcess(ctx context.Context, id string) (*Process, error) {
+	var process Process
+	err := s.client.Get(ctx, fmt.Sprintf("/applications/processes/%s", id), &process)
+	return &process, err
+}
+
+func (s *ApplicationService) UpdateProcess(ctx context.Context, id string, req UpdateProcessRequest) (*Process, error) {
+	// The update endpoint omits the process type and resource type, so fetch the full details afterwards
+	err := s.client.Put(ctx, fmt.Sprintf("/applications/processes/%s", id), req, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetProcess(ctx, id)
+}
+
 // DatabaseService handles database-related API operations.
 type DatabaseService struct {
 	client *Client
@@ -59,9 +176,18 @@ func NewDatabaseService(client *Client) *DatabaseService {
 	return &DatabaseService{client: client}
 }
 
+// List returns all databases of the company, fetching every page.
 func (s *DatabaseService) List(ctx context.Context, companyID string) ([]DatabaseListItem, error) {
+	return listAll(ctx, func(item DatabaseListItem) string { return item.ID },
+		func(ctx context.Context, opts ListOptions) ([]DatabaseListItem, error) {
+			return s.ListPage(ctx, companyID, opts)
+		})
+}
+
+// ListPage returns a single page of the company's databases.
+func (s *DatabaseService) ListPage(ctx context.Context, companyID string, opts ListOptions) ([]DatabaseListItem, error) {
 	var response DatabaseListResponse
-	url := fmt.Sprintf("/databases?company=%s", companyID)
+	url := fmt.Sprintf("/databases?company=%s%s", companyID, opts.query())
 	err := s.client.Get(ctx, url, &response)
 	if err != nil {
 		return nil, err
@@ -69,14 +195,23 @@ func (s *DatabaseService) List(ctx context.Context, companyID string) ([]Databas
 	return response.Company.Databases.Items, nil
 }
 
+// Get returns a database with both its internal and external connection details.
 func (s *DatabaseService) Get(ctx context.Context, id string) (*Database, error) {
+	return s.GetWithOptions(ctx, id, GetDatabaseOptions{Internal: true, External: true})
+}
+
+// GetWithOptions returns a database with the connection details selected by opts. The API
+// requires both query parameters, so each is always sent.
+func (s *DatabaseService) GetWithOptions(ctx context.Context, id string, opts GetDatabaseOptions) (*Database, error) {
 	var db Database
-	// Based on OpenAPI spec, the database GET endpoint requires internal and external query parameters
-	url := fmt.Sprintf("/databases/%s?internal=true&external=true", id)
+	url := fmt.Sprintf("/databases/%s?internal=%s&external=%s", id, strconv.FormatBool(opts.Internal), strconv.FormatBool(opts.External))
 	err := s.client.Get(ctx, url, &db)
 	return &db, err
 }
 
+// Create creates a database and waits until its details can be read. The create endpoint only
+// returns the database ID, not an operation to poll, so Get is retried with exponential backoff
+// while it returns 404 until ctx is done.
 func (s *DatabaseService) Create(ctx context.Context, req CreateDatabaseRequest) (*Database, error) {
 	// The create endpoint only returns the database ID
 	var createResp struct {
@@ -84,25 +219,29 @@ func (s *DatabaseService) Create(ctx context.Context, req CreateDatabaseRequest)
 			ID string `json:"id"`
 		} `json:"database"`
 	}
-	err := s.client.Post(ctx, "/databases", req, &createResp)
+	err := s.client.PostIdempotent(ctx, "/databases", req, &createResp)
 	if err != nil {
 		return nil, err
 	}
-	
-	// Retry getting the database details up to 3 times with a short delay
-	// as the database might not be immediately available after creation
-	var db *Database
-	for i := 0; i < 3; i++ {
-		if i > 0 {
-			time.Sleep(time.Second)
+
+	ctx, cancel := s.client.WithDeadline(ctx)
+	defer cancel()
+
+	backoff := databaseCreateInitialBackoff
+	for {
+		db, err := s.Get(ctx, createResp.Database.ID)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			return db, err
 		}
-		db, err = s.Get(ctx, createResp.Database.ID)
-		if err == nil {
-			break
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("database %s was created but is not readable yet: %w", createResp.Database.ID, context.Cause(ctx))
 		}
+		backoff = min(backoff*2, databaseCreateMaxBackoff)
 	}
-	
-	return db, err
 }
 
 func (s *DatabaseService) Update(ctx context.Context, id string, req UpdateDatabaseRequest) (*Database, error) {
@@ -136,9 +275,18 @@ func NewStaticSiteService(client *Client) *StaticSiteService {
 	return &StaticSiteService{client: client}
 }
 
+// List returns all static sites of the company, fetching every page.
 func (s *StaticSiteService) List(ctx context.Context, companyID string) ([]StaticSiteListItem, error) {
+	return listAll(ctx, func(item StaticSiteListItem) string { return item.ID },
+		func(ctx context.Context, opts ListOptions) ([]StaticSiteListItem, error) {
+			return s.ListPage(ctx, companyID, opts)
+		})
+}
+
+// ListPage returns a single page of the company's static sites.
+func (s *StaticSiteService) ListPage(ctx context.Context, companyID string, opts ListOptions) ([]StaticSiteListItem, error) {
 	var response StaticSiteListResponse
-	url := fmt.Sprintf("/static-sites?company=%s", companyID)
+	url := fmt.Sprintf("/static-sites?company=%s%s", companyID, opts.query())
 	err := s.client.Get(ctx, url, &response)
 	if err != nil {
 		return nil, err
@@ -152,10 +300,46 @@ func (s *StaticSiteService) Get(ctx context.Context, id string) (*StaticSite, er
 	return &site, err
 }
 
+// Create creates a static site and waits until its details are populated. The hostname and git
+// type may be missing from the create response and the first reads, so Get is retried with
+// exponential backoff while it returns 404 or an incomplete site, until ctx is done.
 func (s *StaticSiteService) Create(ctx context.Context, req CreateStaticSiteRequest) (*StaticSite, error) {
 	var site StaticSite
-	err := s.client.Post(ctx, "/static-sites", req, &site)
-	return &site, err
+	if err := s.client.PostIdempotent(ctx, "/static-sites", req, &site); err != nil {
+		return nil, err
+	}
+	if site.StaticSite.ID == "" || isStaticSitePopulated(&site.StaticSite) {
+		return &site, nil
+	}
+
+	ctx, cancel := s.client.WithDeadline(ctx)
+	defer cancel()
+
+	backoff := staticSiteCreateInitialBackoff
+	for {
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("static site %s was created but its details are not available yet: %w", site.StaticSite.ID, context.Cause(ctx))
+		}
+		backoff = min(backoff*2, staticSiteCreateMaxBackoff)
+
+		read, err := s.Get(ctx, site.StaticSite.ID)
+		var apiErr *APIError
+		switch {
+		case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound:
+			continue
+		case err != nil:
+			return nil, err
+		case isStaticSitePopulated(&read.StaticSite):
+			return read, nil
+		}
+	}
+}
+
+// isStaticSitePopulated reports whether the details the API fills in after creation are present.
+func isStaticSitePopulated(site *StaticSiteDetails) bool {
+	return site.Hostname != "" && site.GitType != ""
 }
 
 func (s *StaticSiteService) Update(ctx context.Context, id string, req UpdateStaticSiteRequest) (*StaticSite, error) {
@@ -168,6 +352,19 @@ func (s *StaticSiteService) Delete(ctx context.Context, id string) error {
 	return s.client.Delete(ctx, fmt.Sprintf("/static-sites/%s", id))
 }
 
+// Deploy starts a deployment of the static site, from req.Branch if set or the default branch otherwise.
+func (s *StaticSiteService) Deploy(ctx context.Context, req DeployStaticSiteRequest) (*DeployStaticSiteResponse, error) {
+	var response DeployStaticSiteResponse
+	err := s.client.Post(ctx, "/static-sites/deployments", req, &response)
+	return &response, err
+}
+
+func (s *StaticSiteService) GetDeployment(ctx context.Context, id string) (*StaticSiteDeploymentResponse, error) {
+	var deployment StaticSiteDeploymentResponse
+	err := s.client.Get(ctx, fmt.Sprintf("/static-sites/deployments/%s", id), &deployment)
+	return &deployment, err
+}
+
 // PipelineService handles pipeline-related API operations.
 type PipelineService struct {
 	client *Client
@@ -193,7 +390,7 @@ func (s *PipelineService) Get(ctx context.Context, id string) (*Pipeline, error)
 
 func (s *PipelineService) Create(ctx context.Context, req CreatePipelineRequest) (*Pipeline, error) {
 	var pipeline Pipeline
-	err := s.client.Post(ctx, "/pipelines", req, &pipeline)
+	err := s.client.PostIdempotent(ctx, "/pipelines", req, &pipeline)
 	return &pipeline, err
 }
 
@@ -229,6 +426,36 @@ func (s *DeploymentService) Get(ctx context.Context, appID, deploymentID string)
 	return &deployment, err
 }
 
+// GetByID returns a deployment by its ID alone. Unlike GetLogs, it does not fetch the whole
+// application, so it is the cheaper way to poll a deployment's status.
+func (s *DeploymentService) GetByID(ctx context.Context, deploymentID string) (*ApplicationDeploymentResponse, error) {
+	var deployment ApplicationDeploymentResponse
+	err := s.client.Get(ctx, fmt.Sprintf("/applications/deployments/%s", deploymentID), &deployment)
+	return &deployment, err
+}
+
+// GetLogs returns the build logs of a deployment. The API only reports logs in the application's
+// deployment history, so older deployments that dropped off it return ErrDeploymentNotFound.
+func (s *DeploymentService) GetLogs(ctx context.Context, appID, deploymentID string) (string, error) {
+	var app Application
+	if err := s.client.Get(ctx, fmt.Sprintf("/applications/%s", appID), &app); err != nil {
+		return "", err
+	}
+
+	for _, deployment := range app.App.Deployments {
+		if deployment.ID == deploymentID {
+			return deployment.BuildLogs, nil
+		}
+	}
+	return "", fmt.Errorf("deployment %s of application %s: %w", deploymentID, appID, ErrDeploymentNotFound)
+}
+
+func (s *DeploymentService) Trigger(ctx context.Context, req TriggerDeploymentRequest) (*TriggerDeploymentResponse, error) {
+	var resp TriggerDeploymentResponse
+	err := s.client.Post(ctx, "/applications/deployments", req, &resp)
+	return &resp, err
+}
+
 // SiteService handles WordPress site-related API operations.
 type SiteService struct {
 	client *Client
@@ -239,9 +466,20 @@ func NewSiteService(client *Client) *SiteService {
 	return &SiteService{client: client}
 }
 
+// List returns all WordPress sites of the company, fetching every page.
 func (s *SiteService) List(ctx context.Context, companyID string) ([]SiteListItem, error) {
+	return listAll(ctx, func(item SiteListItem) string { return item.ID },
+		func(ctx context.Context, opts ListOptions) ([]SiteListItem, error) {
+			return s.ListPage(ctx, companyID, opts)
+		})
+}
+
+// ListPage returns a single page of the company's WordPress sites.
+// The sites endpoint is not documented as paginated; if it ignores the options,
+// List stops after the first repeated page.
+func (s *SiteService) ListPage(ctx context.Context, companyID string, opts ListOptions) ([]SiteListItem, error) {
 	var response SiteListResponse
-	url := fmt.Sprintf("/sites?company=%s", companyID)
+	url := fmt.Sprintf("/sites?company=%s%s", companyID, opts.query())
 	err := s.client.Get(ctx, url, &response)
 	if err != nil {
 		return nil, err
@@ -257,7 +495,7 @@ func (s *SiteService) Get(ctx context.Context, id string) (*Site, error) {
 
 func (s *SiteService) Create(ctx context.Context, req CreateSiteRequest) (*OperationResponse, error) {
 	var opResp OperationResponse
-	err := s.client.Post(ctx, "/sites", req, &opResp)
+	err := s.client.PostIdempotent(ctx, "/sites", req, &opResp)
 	return &opResp, err
 }
 
@@ -271,6 +509,55 @@ func (s *SiteService) Delete(ctx context.Context, id string) error {
 	return s.client.Delete(ctx, fmt.Sprintf("/sites/%s", id))
 }
 
+// AddDomain starts adding a domain to a site environment.
+func (s *SiteService) AddDomain(ctx context.Context, environmentID string, req AddSiteDomainRequest) (*OperationResponse, error) {
+	var opResp OperationResponse
+	err := s.client.Post(ctx, fmt.Sprintf("/sites/environments/%s/domains", environmentID), req, &opResp)
+	return &opResp, err
+}
+
+// DeleteDomains starts removing domains from a site environment.
+func (s *SiteService) DeleteDomains(ctx context.Context, environmentID string, domainIDs []string) (*OperationResponse, error) {
+	var opResp OperationResponse
+	err := s.client.DeleteWithBody(ctx, fmt.Sprintf("/sites/environments/%s/domains", environmentID),
+		DeleteSiteDomainsRequest{DomainIDs: domainIDs}, &opResp)
+	return &opResp, err
+}
+
+// CreateEnvironment starts adding an environment to a site. The environment is cloned from
+// req.CloneFromEnvironmentID when set, and created without WordPress installed otherwise.
+func (s *SiteService) CreateEnvironment(ctx context.Context, siteID string, req CreateEnvironmentRequest) (*OperationResponse, error) {
+	path := fmt.Sprintf("/sites/%s/environments/plain", siteID)
+	if req.CloneFromEnvironmentID != "" {
+		path = fmt.Sprintf("/sites/%s/environments/clone", siteID)
+	}
+
+	var opResp OperationResponse
+	err := s.client.PostIdempotent(ctx, path, req, &opResp)
+	return &opResp, err
+}
+
+// PromoteEnvironment starts pushing the files and database of a site environment onto the
+// target environment, usually the live one, replacing the target's content.
+func (s *SiteService) PromoteEnvironment(ctx context.Context, siteID, environmentID, targetEnvironmentID string) (*OperationResponse, error) {
+	var opResp OperationResponse
+	err := s.client.Put(ctx, fmt.Sprintf("/sites/%s/environments", siteID), PushEnvironmentRequest{
+		SourceEnvironmentID: environmentID,
+		TargetEnvironmentID: targetEnvironmentID,
+		PushDB:              true,
+		PushFiles:           true,
+		RunSearchAndReplace: true,
+	}, &opResp)
+	return &opResp, err
+}
+
+// DeleteEnvironment starts deleting a site environment.
+func (s *SiteService) DeleteEnvironment(ctx context.Context, environmentID string) (*OperationResponse, error) {
+	var opResp OperationResponse
+	err := s.client.DeleteWithBody(ctx, fmt.Sprintf("/sites/environments/%s", environmentID), nil, &opResp)
+	return &opResp, err
+}
+
 // CompanyService handles company-related API operations.
 type CompanyService struct {
 	client *Client
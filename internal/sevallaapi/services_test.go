@@ -0,0 +1,656 @@
+package sevallaapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sriniously/terraform-provider-sevalla/internal/sevallaapi/sevallaapitest"
+)
+
+func newTestClient(t *testing.T) (*Client, *sevallaapitest.Server) {
+	t.Helper()
+
+	server := sevallaapitest.NewServer(t)
+	client := NewClient(Config{
+		BaseURL: server.URL,
+		Token:   "test-token",
+	})
+
+	return client, server
+}
+
+func TestApplicationService_Get(t *testing.T) {
+	client, server := newTestClient(t)
+
+	app, err := client.Applications.Get(context.Background(), sevallaapitest.ApplicationID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if app.App.ID != sevallaapitest.ApplicationID {
+		t.Errorf("expected id %q, got %q", sevallaapitest.ApplicationID, app.App.ID)
+	}
+	if len(app.App.Deployments) != 1 || app.App.Deployments[0].CommitHash != "a1b2c3d" {
+		t.Errorf("unexpected deployments: %+v", app.App.Deployments)
+	}
+	if len(app.App.Processes) != 1 || app.App.Processes[0].Key != "web" {
+		t.Errorf("unexpected processes: %+v", app.App.Processes)
+	}
+
+	req, ok := server.LastRequest()
+	if !ok {
+		t.Fatal("expected a request to be recorded")
+	}
+	if req.Method != http.MethodGet || req.Path != "/applications/"+sevallaapitest.ApplicationID {
+		t.Errorf("unexpected request %s %s", req.Method, req.Path)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer test-token" {
+		t.Errorf("unexpected Authorization header %q", got)
+	}
+}
+
+func TestApplicationService_List(t *testing.T) {
+	client, server := newTestClient(t)
+
+	apps, err := client.Applications.List(context.Background(), sevallaapitest.CompanyID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(apps) != 1 || apps[0].ID != sevallaapitest.ApplicationID {
+		t.Errorf("unexpected applications: %+v", apps)
+	}
+
+	req, _ := server.LastRequest()
+	if req.Path != "/applications" {
+		t.Errorf("unexpected path %q", req.Path)
+	}
+	if got := req.Query.Get("company"); got != sevallaapitest.CompanyID {
+		t.Errorf("expected company query %q, got %q", sevallaapitest.CompanyID, got)
+	}
+}
+
+func TestApplicationService_Update(t *testing.T) {
+	client, server := newTestClient(t)
+
+	displayName := "renamed"
+	_, err := client.Applications.Update(context.Background(), sevallaapitest.ApplicationID, UpdateApplicationRequest{
+		DisplayName: &displayName,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req, _ := server.LastRequest()
+	if req.Method != http.MethodPut {
+		t.Errorf("expected PUT, got %s", req.Method)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(req.Body, &body); err != nil {
+		t.Fatalf("unable to decode request body: %s", err)
+	}
+	if body["display_name"] != displayName {
+		t.Errorf("expected display_name %q, got %v", displayName, body["display_name"])
+	}
+	if _, ok := body["build_type"]; ok {
+		t.Error("expected unset fields to be omitted from the request body")
+	}
+}
+
+func TestApplicationService_GetNotFound(t *testing.T) {
+	client, server := newTestClient(t)
+	server.HandleJSON(http.MethodGet, "/applications/{id}", http.StatusNotFound, `{"message":"App not found","status":404}`)
+
+	_, err := client.Applications.Get(context.Background(), "missing")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if err.Error() != "HTTP 404: App not found" {
+		t.Errorf("unexpected error %q", err)
+	}
+}
+
+func TestDatabaseService_Get(t *testing.T) {
+	client, server := newTestClient(t)
+
+	db, err := client.Databases.Get(context.Background(), sevallaapitest.DatabaseID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if db.Database.Cluster.Location != "us-central1" {
+		t.Errorf("unexpected cluster: %+v", db.Database.Cluster)
+	}
+	if db.Database.Data.DBUser == nil || *db.Database.Data.DBUser != "myuser" {
+		t.Errorf("unexpected db user: %v", db.Database.Data.DBUser)
+	}
+
+	req, _ := server.LastRequest()
+	if req.Path != "/databases/"+sevallaapitest.DatabaseID {
+		t.Errorf("unexpected path %q", req.Path)
+	}
+	if req.Query.Get("internal") != "true" || req.Query.Get("external") != "true" {
+		t.Errorf("expected internal and external query parameters, got %v", req.Query)
+	}
+}
+
+func TestDatabaseService_GetWithOptions(t *testing.T) {
+	client, server := newTestClient(t)
+
+	tests := map[string]struct {
+		opts     GetDatabaseOptions
+		internal string
+		external string
+	}{
+		"both":          {GetDatabaseOptions{Internal: true, External: true}, "true", "true"},
+		"internal only": {GetDatabaseOptions{Internal: true}, "true", "false"},
+		"external only": {GetDatabaseOptions{External: true}, "false", "true"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := client.Databases.GetWithOptions(context.Background(), sevallaapitest.DatabaseID, tt.opts); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			req, _ := server.LastRequest()
+			if req.Query.Get("internal") != tt.internal || req.Query.Get("external") != tt.external {
+				t.Errorf("expected internal=%s&external=%s, got %s", tt.internal, tt.external, req.Query.Encode())
+			}
+		})
+	}
+}
+
+func TestDatabaseService_Create(t *testing.T) {
+	client, server := newTestClient(t)
+
+	db, err := client.Databases.Create(context.Background(), CreateDatabaseRequest{
+		CompanyID:    sevallaapitest.CompanyID,
+		Location:     "us-central1",
+		ResourceType: string(ResourceTypeDB1),
+		DisplayName:  "my-db",
+		DBName:       "mydb",
+		DBPassword:   "secret",
+		Type:         string(DatabaseTypePostgreSQL),
+		Version:      "14",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if db.Database.ID != sevallaapitest.DatabaseID {
+		t.Errorf("expected id %q, got %q", sevallaapitest.DatabaseID, db.Database.ID)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected create followed by get, got %d requests", len(requests))
+	}
+	if requests[0].Method != http.MethodPost || requests[1].Method != http.MethodGet {
+		t.Errorf("unexpected request sequence %s, %s", requests[0].Method, requests[1].Method)
+	}
+	if !strings.Contains(string(requests[0].Body), `"resource_type":"db1"`) {
+		t.Errorf("unexpected create body %s", requests[0].Body)
+	}
+}
+
+func TestDatabaseService_CreateWaitsForDatabase(t *testing.T) {
+	databaseCreateInitialBackoff = time.Millisecond
+	t.Cleanup(func() { databaseCreateInitialBackoff = 500 * time.Millisecond })
+
+	client, server := newTestClient(t)
+	var gets int
+	server.Handle(http.MethodGet, "/databases/{id}", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		if gets <= 2 {
+			sevallaapitest.JSONResponse(http.StatusNotFound, `{"message":"Database not found","status":404}`)(w, r)
+			return
+		}
+		sevallaapitest.JSONResponse(http.StatusOK, sevallaapitest.DatabaseFixture)(w, r)
+	})
+
+	db, err := client.Databases.Create(context.Background(), CreateDatabaseRequest{
+		CompanyID:   sevallaapitest.CompanyID,
+		DisplayName: "my-db",
+		Type:        string(DatabaseTypePostgreSQL),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if db.Database.ID != sevallaapitest.DatabaseID {
+		t.Errorf("expected id %q, got %q", sevallaapitest.DatabaseID, db.Database.ID)
+	}
+	if gets != 3 {
+		t.Errorf("expected 3 reads of the database, got %d", gets)
+	}
+}
+
+func TestDatabaseService_CreateStopsWhenContextEnds(t *testing.T) {
+	databaseCreateInitialBackoff = time.Millisecond
+	t.Cleanup(func() { databaseCreateInitialBackoff = 500 * time.Millisecond })
+
+	client, server := newTestClient(t)
+	server.HandleJSON(http.MethodGet, "/databases/{id}", http.StatusNotFound, `{"message":"Database not found","status":404}`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Databases.Create(ctx, CreateDatabaseRequest{CompanyID: sevallaapitest.CompanyID})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the wait to end with the context, got %v", err)
+	}
+}
+
+func TestSiteService_Create(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	op, err := client.Sites.Create(context.Background(), CreateSiteRequest{
+		CompanyID:   sevallaapitest.CompanyID,
+		DisplayName: "My WP Site",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if op.OperationID != sevallaapitest.OperationID {
+		t.Errorf("expected operation id %q, got %q", sevallaapitest.OperationID, op.OperationID)
+	}
+}
+
+func TestSiteService_Get(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	site, err := client.Sites.Get(context.Background(), sevallaapitest.SiteID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(site.Site.Environments) != 1 || site.Site.Environments[0].PrimaryDomain.Name == "" {
+		t.Errorf("unexpected environments: %+v", site.Site.Environments)
+	}
+}
+
+func TestSiteService_CreateEnvironment(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req  CreateEnvironmentRequest
+		path string
+		body string
+	}{
+		"plain": {
+			req:  CreateEnvironmentRequest{DisplayName: "staging"},
+			path: "/sites/site-1/environments/plain",
+			body: `{"display_name":"staging","is_premium":false}`,
+		},
+		"clone": {
+			req:  CreateEnvironmentRequest{DisplayName: "staging", CloneFromEnvironmentID: "env-1"},
+			path: "/sites/site-1/environments/clone",
+			body: `{"display_name":"staging","is_premium":false,"source_env_id":"env-1"}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client, server := newTestClient(t)
+
+			op, err := client.Sites.CreateEnvironment(context.Background(), sevallaapitest.SiteID, tc.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if op.OperationID != sevallaapitest.OperationID {
+				t.Errorf("expected operation id %q, got %q", sevallaapitest.OperationID, op.OperationID)
+			}
+
+			req, _ := server.LastRequest()
+			if req.Path != tc.path || strings.TrimSpace(string(req.Body)) != tc.body {
+				t.Errorf("unexpected request %s with body %s", req.Path, req.Body)
+			}
+		})
+	}
+}
+
+func TestSiteService_PromoteEnvironment(t *testing.T) {
+	client, server := newTestClient(t)
+
+	op, err := client.Sites.PromoteEnvironment(context.Background(), sevallaapitest.SiteID, "env-2", "env-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if op.OperationID != sevallaapitest.OperationID {
+		t.Errorf("expected operation id %q, got %q", sevallaapitest.OperationID, op.OperationID)
+	}
+
+	req, _ := server.LastRequest()
+	want := `{"source_env_id":"env-2","target_env_id":"env-1","push_db":true,"push_files":true,"run_search_and_replace":true}`
+	if req.Method != http.MethodPut || req.Path != "/sites/site-1/environments" || strings.TrimSpace(string(req.Body)) != want {
+		t.Errorf("unexpected request %s %s with body %s", req.Method, req.Path, req.Body)
+	}
+}
+
+func TestOperationService_GetStatus(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	op, err := client.Operations.GetStatus(context.Background(), sevallaapitest.OperationID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if op.Status != "completed" || op.ResourceID != sevallaapitest.SiteID {
+		t.Errorf("unexpected operation: %+v", op)
+	}
+	if op.CompletedAt == nil {
+		t.Error("expected completed_at to be set")
+	}
+}
+
+func TestDeploymentService_GetLogs(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	logs, err := client.Deployments.GetLogs(context.Background(), sevallaapitest.ApplicationID, sevallaapitest.DeploymentID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if logs != "Build succeeded" {
+		t.Errorf("unexpected build logs %q", logs)
+	}
+
+	_, err = client.Deployments.GetLogs(context.Background(), sevallaapitest.ApplicationID, "missing")
+	if !errors.Is(err, ErrDeploymentNotFound) {
+		t.Errorf("expected ErrDeploymentNotFound, got %v", err)
+	}
+}
+
+func TestApplicationService_GetHTTPRequestMetrics(t *testing.T) {
+	client, server := newTestClient(t)
+
+	metrics, err := client.Applications.GetHTTPRequestMetrics(context.Background(), sevallaapitest.ApplicationID, MetricsQuery{
+		StartDate: "2024-01-01",
+		EndDate:   "2024-01-02",
+		Interval:  "day",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(metrics.Data) != 2 || metrics.Data[0] != 120 || metrics.Data[1] != 95.5 {
+		t.Errorf("unexpected data %v", metrics.Data)
+	}
+	if len(metrics.Timeframe) != 2 || metrics.Timeframe[1] != "2024-01-02T00:00:00Z" {
+		t.Errorf("unexpected timeframe %v", metrics.Timeframe)
+	}
+
+	req, _ := server.LastRequest()
+	if req.Path != "/applications/app-1/metrics/http-requests" {
+		t.Errorf("unexpected path %s", req.Path)
+	}
+	if req.Query.Get("interval_in_seconds") != "86400" ||
+		req.Query.Get("timeframe_start") != "2024-01-01T00:00:00Z" ||
+		req.Query.Get("timeframe_end") != "2024-01-03T00:00:00Z" {
+		t.Errorf("unexpected query %v", req.Query)
+	}
+}
+
+func TestMetricsQuery_Invalid(t *testing.T) {
+	for name, query := range map[string]MetricsQuery{
+		"start date": {StartDate: "01/01/2024", EndDate: "2024-01-02", Interval: "day"},
+		"end date":   {StartDate: "2024-01-01", EndDate: "2024-02-30", Interval: "day"},
+		"reversed":   {StartDate: "2024-01-02", EndDate: "2024-01-01", Interval: "day"},
+		"interval":   {StartDate: "2024-01-01", EndDate: "2024-01-02", Interval: "minute"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := query.query(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestApplicationService_UpdateProcess(t *testing.T) {
+	client, server := newTestClient(t)
+
+	process, err := client.Applications.UpdateProcess(context.Background(), sevallaapitest.ProcessID, UpdateProcessRequest{
+		ScalingStrategy: &ScalingStrategy{
+			Type:   "horizontal",
+			Config: map[string]interface{}{"minInstanceCount": 1, "maxInstanceCount": 3},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if process.Process.ResourceTypeName != "s1" {
+		t.Errorf("expected the full process to be fetched after the update, got %+v", process.Process)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 2 || requests[0].Method != http.MethodPut || requests[1].Method != http.MethodGet {
+		t.Fatalf("expected PUT followed by GET, got %+v", requests)
+	}
+	if !strings.Contains(string(requests[0].Body), `"config":{"maxInstanceCount":3,"minInstanceCount":1}`) {
+		t.Errorf("unexpected update body %s", requests[0].Body)
+	}
+	if strings.Contains(string(requests[0].Body), "entrypoint") {
+		t.Errorf("expected unset entrypoint to be omitted, got %s", requests[0].Body)
+	}
+}
+
+func TestApplicationService_UpdateIfUnmodified(t *testing.T) {
+	const updatedAt = 1695300630620
+
+	t.Run("unchanged", func(t *testing.T) {
+		client, server := newTestClient(t)
+
+		_, err := client.Applications.UpdateIfUnmodified(context.Background(), sevallaapitest.ApplicationID, updatedAt, UpdateApplicationRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		req, _ := server.LastRequest()
+		if req.Method != http.MethodPut {
+			t.Fatalf("expected PUT, got %s", req.Method)
+		}
+		if got := req.Header.Get("If-Match"); got != "1695300630620" {
+			t.Errorf("unexpected If-Match header %q", got)
+		}
+	})
+
+	t.Run("modified since read", func(t *testing.T) {
+		client, server := newTestClient(t)
+
+		_, err := client.Applications.UpdateIfUnmodified(context.Background(), sevallaapitest.ApplicationID, updatedAt-1, UpdateApplicationRequest{})
+		if !IsConflict(err) {
+			t.Fatalf("expected a conflict error, got %v", err)
+		}
+
+		for _, req := range server.Requests() {
+			if req.Method == http.MethodPut {
+				t.Error("expected no update to be sent")
+			}
+		}
+	})
+
+	t.Run("precondition failed", func(t *testing.T) {
+		client, server := newTestClient(t)
+		server.HandleJSON(http.MethodPut, "/applications/{id}", http.StatusPreconditionFailed, `{"message":"Version mismatch","status":412}`)
+
+		_, err := client.Applications.UpdateIfUnmodified(context.Background(), sevallaapitest.ApplicationID, updatedAt, UpdateApplicationRequest{})
+		if !IsConflict(err) {
+			t.Fatalf("expected a conflict error, got %v", err)
+		}
+		if err.Error() != "HTTP 412: Version mismatch" {
+			t.Errorf("unexpected error %q", err)
+		}
+	})
+}
+
+func TestStaticSiteService_CreateWaitsForDetails(t *testing.T) {
+	staticSiteCreateInitialBackoff = time.Millisecond
+	t.Cleanup(func() { staticSiteCreateInitialBackoff = 500 * time.Millisecond })
+
+	client, server := newTestClient(t)
+	server.HandleJSON(http.MethodPost, "/static-sites", http.StatusOK, `{"static_site": {"id": "static-1", "status": "deploying"}}`)
+	var gets int
+	server.Handle(http.MethodGet, "/static-sites/{id}", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		switch gets {
+		case 1:
+			sevallaapitest.JSONResponse(http.StatusNotFound, `{"message":"Static site not found","status":404}`)(w, r)
+		case 2:
+			sevallaapitest.JSONResponse(http.StatusOK, `{"static_site": {"id": "static-1", "git_type": "github"}}`)(w, r)
+		default:
+			sevallaapitest.JSONResponse(http.StatusOK, sevallaapitest.StaticSiteFixture)(w, r)
+		}
+	})
+
+	site, err := client.StaticSites.Create(context.Background(), CreateStaticSiteRequest{
+		CompanyID:   sevallaapitest.CompanyID,
+		DisplayName: "my-site",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if site.StaticSite.Hostname != "my-site-abc12.sevalla.page" || site.StaticSite.GitType != "github" {
+		t.Errorf("expected a fully populated static site, got %+v", site.StaticSite)
+	}
+	if gets != 3 {
+		t.Errorf("expected 3 reads of the static site, got %d", gets)
+	}
+}
+
+func TestStaticSiteService_CreateReadErrors(t *testing.T) {
+	staticSiteCreateInitialBackoff = time.Millisecond
+	t.Cleanup(func() { staticSiteCreateInitialBackoff = 500 * time.Millisecond })
+
+	t.Run("incomplete until ctx is done", func(t *testing.T) {
+		client, server := newTestClient(t)
+		server.HandleJSON(http.MethodPost, "/static-sites", http.StatusOK, `{"static_site": {"id": "static-1"}}`)
+		server.HandleJSON(http.MethodGet, "/static-sites/{id}", http.StatusOK, `{"static_site": {"id": "static-1", "git_type": "github"}}`)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.StaticSites.Create(ctx, CreateStaticSiteRequest{CompanyID: sevallaapitest.CompanyID})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the deadline to be returned, got %v", err)
+		}
+		if !strings.Contains(err.Error(), sevallaapitest.StaticSiteID) {
+			t.Errorf("expected the error to name the created static site, got %q", err)
+		}
+	})
+
+	t.Run("non-404 error", func(t *testing.T) {
+		client, server := newTestClient(t)
+		server.HandleJSON(http.MethodPost, "/static-sites", http.StatusOK, `{"static_site": {"id": "static-1"}}`)
+		var gets int
+		server.Handle(http.MethodGet, "/static-sites/{id}", func(w http.ResponseWriter, r *http.Request) {
+			gets++
+			sevallaapitest.JSONResponse(http.StatusForbidden, `{"message":"Forbidden","status":403}`)(w, r)
+		})
+
+		_, err := client.StaticSites.Create(context.Background(), CreateStaticSiteRequest{CompanyID: sevallaapitest.CompanyID})
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected the 403 to be returned, got %v", err)
+		}
+		if gets != 1 {
+			t.Errorf("expected the read not to be retried, got %d reads", gets)
+		}
+	})
+}
+
+func TestStaticSiteService_Deploy(t *testing.T) {
+	client, server := newTestClient(t)
+
+	deployed, err := client.StaticSites.Deploy(context.Background(), DeployStaticSiteRequest{
+		StaticSiteID: sevallaapitest.StaticSiteID,
+		Branch:       "feature/preview",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if deployed.Deployment.ID != sevallaapitest.StaticSiteDeploymentID {
+		t.Errorf("expected deployment id %q, got %q", sevallaapitest.StaticSiteDeploymentID, deployed.Deployment.ID)
+	}
+
+	req, _ := server.LastRequest()
+	if req.Method != http.MethodPost || req.Path != "/static-sites/deployments" {
+		t.Fatalf("unexpected request %s %s", req.Method, req.Path)
+	}
+	if want := `{"static_site_id":"static-1","branch":"feature/preview"}`; string(req.Body) != want {
+		t.Errorf("expected body %s, got %s", want, req.Body)
+	}
+
+	deployment, err := client.StaticSites.GetDeployment(context.Background(), deployed.Deployment.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if deployment.Deployment.CommitSHA == nil || *deployment.Deployment.CommitSHA != "e4f5a6b" {
+		t.Errorf("unexpected commit sha %v", deployment.Deployment.CommitSHA)
+	}
+}
+
+func TestApplicationService_ListPaginated(t *testing.T) {
+	client, server := newTestClient(t)
+
+	pages := map[string]string{
+		"0": `{"company": {"apps": {"items": [{"id": "app-1"}, {"id": "app-2"}]}}}`,
+		"2": `{"company": {"apps": {"items": [{"id": "app-3"}]}}}`,
+		"3": `{"company": {"apps": {"items": []}}}`,
+	}
+	server.Handle(http.MethodGet, "/applications", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := pages[r.URL.Query().Get("offset")]
+		if !ok {
+			t.Errorf("unexpected offset %q", r.URL.Query().Get("offset"))
+			body = pages["3"]
+		}
+		sevallaapitest.JSONResponse(http.StatusOK, body)(w, r)
+	})
+
+	apps, err := client.Applications.List(context.Background(), sevallaapitest.CompanyID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var ids []string
+	for _, app := range apps {
+		ids = append(ids, app.ID)
+	}
+	if strings.Join(ids, ",") != "app-1,app-2,app-3" {
+		t.Errorf("expected all pages to be aggregated, got %v", ids)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 page requests, got %d", len(requests))
+	}
+	for _, req := range requests {
+		if req.Query.Get("company") != sevallaapitest.CompanyID || req.Query.Get("limit") != "100" {
+			t.Errorf("unexpected query %v", req.Query)
+		}
+	}
+}
+
+func TestSiteService_ListIgnoresOffset(t *testing.T) {
+	client, server := newTestClient(t)
+
+	// The default fixture returns the same page regardless of offset
+	sites, err := client.Sites.List(context.Background(), sevallaapitest.CompanyID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(sites) != 1 {
+		t.Errorf("expected the repeated page to be listed once, got %d sites", len(sites))
+	}
+	if got := len(server.Requests()); got != 2 {
+		t.Errorf("expected listing to stop after the repeated page, got %d requests", got)
+	}
+}
+
+func TestApplicationService_Restart(t *testing.T) {
+	client, server := newTestClient(t)
+
+	if _, err := client.Applications.Restart(context.Background(), sevallaapitest.ApplicationID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req, _ := server.LastRequest()
+	if req.Path != "/applications/deployments" || strings.TrimSpace(string(req.Body)) != `{"app_id":"app-1","is_restart":true}` {
+		t.Errorf("unexpected request %s with body %s", req.Path, req.Body)
+	}
+}
@@ -0,0 +1,150 @@
+// Package sevallaapitest provides a fake Sevalla API server for tests.
+package sevallaapitest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Request records a request received by the fake server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+type route struct {
+	method   string
+	segments []string
+	handler  http.HandlerFunc
+}
+
+// Server is an httptest-based fake of the Sevalla API that serves canned
+// fixtures for each endpoint. Routes can be overridden per test with Handle.
+type Server struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	routes   []route
+	requests []Request
+}
+
+// NewServer starts a fake Sevalla API server pre-loaded with the default
+// fixtures. The server is closed automatically when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{}
+	s.registerDefaults()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Handle registers a handler for a method and path pattern. Path segments
+// wrapped in braces, such as /applications/{id}, match any single segment.
+// Routes registered later take precedence over earlier ones.
+func (s *Server) Handle(method, pattern string, handler http.HandlerFunc) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.routes = append(s.routes, route{
+		method:   method,
+		segments: splitPath(pattern),
+		handler:  handler,
+	})
+}
+
+// HandleJSON registers a route that always responds with the given status and JSON body.
+func (s *Server) HandleJSON(method, pattern string, status int, body string) {
+	s.Handle(method, pattern, JSONResponse(status, body))
+}
+
+// Requests returns a copy of every request received so far.
+func (s *Server) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	requests := make([]Request, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// LastRequest returns the most recent request received, or false if there was none.
+func (s *Server) LastRequest() (Request, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.requests) == 0 {
+		return Request{}, false
+	}
+	return s.requests[len(s.requests)-1], true
+}
+
+// JSONResponse returns a handler that writes the given status and JSON body.
+func JSONResponse(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mutex.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	handler := s.match(r.Method, splitPath(r.URL.Path))
+	s.mutex.Unlock()
+
+	if handler == nil {
+		JSONResponse(http.StatusNotFound, `{"message":"no fixture for `+r.Method+` `+r.URL.Path+`","status":404}`)(w, r)
+		return
+	}
+
+	r.Body = io.NopCloser(strings.NewReader(string(body)))
+	handler(w, r)
+}
+
+// match must be called with the mutex held.
+func (s *Server) match(method string, segments []string) http.HandlerFunc {
+	for i := len(s.routes) - 1; i >= 0; i-- {
+		rt := s.routes[i]
+		if rt.method != method || len(rt.segments) != len(segments) {
+			continue
+		}
+		matched := true
+		for j, segment := range rt.segments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				continue
+			}
+			if segment != segments[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rt.handler
+		}
+	}
+	return nil
+}
+
+func splitPath(p string) []string {
+	return strings.Split(strings.Trim(p, "/"), "/")
+}